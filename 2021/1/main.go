@@ -1,13 +1,13 @@
-package main
+package day1
 
 import (
-  "fmt"
+  "embed"
   "strconv"
   "aoc/utils"
 )
 
-func main() {
-  lines := utils.ReadLines("inp1.txt")
+func parseReadings(input string) []int {
+  lines := utils.NonEmptyLines(input)
 
   var readings[] int
 
@@ -24,14 +24,17 @@ func main() {
 
     readings = append(readings, n)
   }
+  return readings
+}
 
+func countIncreases(readings []int, window int) int {
 
-  curr := utils.Sum(readings[:3])
+  curr := utils.Sum(readings[:window])
   increases := 0
 
-  for i := 1; i < len(readings) - 2; i++ {
+  for i := 1; i < len(readings) - window + 1; i++ {
 
-    tot := utils.Sum(readings[i:i+3])
+    tot := utils.Sum(readings[i:i+window])
 
     if curr != -1 {
       if tot > curr {
@@ -42,5 +45,27 @@ func main() {
     curr = tot
   }
 
-  fmt.Println("res: ", increases)
+  return increases
+}
+
+type solution struct{}
+
+func (solution) Parse(input string) []int {
+  return parseReadings(input)
+}
+
+func (solution) Part1(readings []int) any {
+  return countIncreases(readings, 1)
+}
+
+func (solution) Part2(readings []int) any {
+  return countIncreases(readings, 3)
+}
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+  utils.Register[[]int](2021, 1, solution{})
+  utils.RegisterExamples(2021, 1, examples)
 }
@@ -1,7 +1,7 @@
-package main
+package day10
 
 import (
-  "fmt"
+  "embed"
   "sort"
   "strings"
 
@@ -66,8 +66,7 @@ func walkLine(l string) (LineStatus, int) {
   return Good, 0
 }
 
-func main() {
-  lines := utils.ReadLines("inp.txt")
+func scoreLines(lines []string) (int, []int) {
   corr := 0
   var inc []int
   for _, l := range lines {
@@ -80,5 +79,29 @@ func main() {
     }
   }
   sort.Ints(inc)
-  fmt.Println("corr", corr, "inc", inc[len(inc)/2])
+  return corr, inc
+}
+
+type solution struct{}
+
+func (solution) Parse(input string) []string {
+  return utils.NonEmptyLines(input)
+}
+
+func (solution) Part1(lines []string) any {
+  corr, _ := scoreLines(lines)
+  return corr
+}
+
+func (solution) Part2(lines []string) any {
+  _, inc := scoreLines(lines)
+  return inc[len(inc)/2]
+}
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+  utils.Register[[]string](2021, 10, solution{})
+  utils.RegisterExamples(2021, 10, examples)
 }
@@ -1,73 +1,32 @@
-package main
+package day11
 
 import (
-  "fmt"
-  "strings"
-  "strconv"
+  "embed"
 
   "aoc/utils"
 )
 
-type Grid [][]int
-
-var adjRange = []int{-1, 0, 1}
-
-func loadGrid(lines []string) Grid {
-  var grid Grid
-  for _, l := range lines {
-    grid = append(grid, utils.StrsToInts(strings.Split(l, "")))
-  }
-  return grid
-}
-
-func printGrid(grid Grid) {
-  var lines []string
-  for _, r := range grid {
-    var l []string
-    for _, v := range r {
-      if v == 0 {
-        l = append(l, fmt.Sprintf("\x1b[31m%d\033[0m", v))
-      } else {
-        l = append(l, strconv.Itoa(v))
-      }
-    }
-    lines = append(lines, strings.Join(l, ""))
-  }
-  fmt.Println(strings.Join(lines, "\n"))
-}
-
-func stepGrid(grid Grid) int {
-  for i, r := range grid {
-    for j := range r {
-      grid[i][j] += 1
-    }
+// Advance the octopuses one step, returning how many flashed
+func stepGrid(grid *utils.IntGrid) int {
+  for it := grid.Iter(); it.Next(); {
+    *grid.Ptr(it.V) += 1
   }
 
   flashCount := 0
   for {
     flash := false
-    for i, r := range grid {
-      for j, v := range r {
-        if v > 9 {
-          grid[i][j] = 0
-          flash = true
-          flashCount++
-
-          for _, di := range adjRange {
-            for _, dj := range adjRange {
-              x := i + di
-              y := j + dj
-              if (x < 0 || x >= len(grid)) || (y < 0 || y >= len(grid[0])) {
-                continue
-              }
-              // this will also catch the case where we're re-updating the current spot
-              if grid[x][y] == 0 {
-                continue
-              }
+    for it := grid.Iter(); it.Next(); {
+      if grid.At(it.V) <= 9 {
+        continue
+      }
+      grid.Set(it.V, 0)
+      flash = true
+      flashCount++
 
-              grid[x][y] += 1
-            }
-          }
+      for nb := grid.NeighborIter(it.V, utils.AllNeighbors); nb.Next(); {
+        // octopuses that already flashed this step stay at 0
+        if grid.At(nb.V) != 0 {
+          *grid.Ptr(nb.V) += 1
         }
       }
     }
@@ -79,19 +38,32 @@ func stepGrid(grid Grid) int {
   return flashCount
 }
 
-func main() {
-  lines := utils.ReadLines("inp.txt")
-  grid := loadGrid(lines)
+type solution struct{}
+
+func (solution) Parse(input string) utils.IntGrid {
+  return utils.ParseDigitGrid(utils.NonEmptyLines(input))
+}
+
+func (solution) Part1(grid utils.IntGrid) any {
   totFlash := 0
-  i := 0
-  for ; ; i++ {
-    res := stepGrid(grid)
-    if i < 100 {
-      totFlash += res
-    }
-    if res == 100 {
-      break
-    }
+  for i := 0; i < 100; i++ {
+    totFlash += stepGrid(&grid)
   }
-  fmt.Println("tot(at 100)", totFlash, "first all flash", i+1)
+  return totFlash
+}
+
+func (solution) Part2(grid utils.IntGrid) any {
+  step := 1
+  for stepGrid(&grid) != grid.H()*grid.W() {
+    step++
+  }
+  return step
+}
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+  utils.Register[utils.IntGrid](2021, 11, solution{})
+  utils.RegisterExamples(2021, 11, examples)
 }
@@ -0,0 +1,11 @@
+package day11
+
+import (
+  "testing"
+
+  "aoc/utils/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+  aoctest.TestExamples(t, 2021, 11)
+}
@@ -1,7 +1,7 @@
-package main
+package day12
 
 import (
-  "fmt"
+  "embed"
   "strings"
 
   "aoc/utils"
@@ -64,14 +64,14 @@ func isUpper(s string) bool {
 func addNode(adj AdjList, k, v string) {
     s, ok := adj[k]
     if !ok {
-      adj[k] = utils.NewStrSet().Add(v)
+      adj[k] = utils.NewStrSet(v)
     } else {
       s.Add(v)
     }
 }
 
-func parseInput(filename string) AdjList {
-  lines := utils.ReadLines(filename)
+func parseInput(input string) AdjList {
+  lines := utils.NonEmptyLines(input)
 
   adj := AdjList{}
 
@@ -123,7 +123,7 @@ func findPaths(adj AdjList, part int) []*Path {
     }
 
     opts, _ := adj[p.Last()]
-    for n := range opts {
+    for _, n := range opts.Values() {
       good := true
       switch part {
         case 1:
@@ -141,12 +141,24 @@ func findPaths(adj AdjList, part int) []*Path {
   return complete
 }
 
-func main() {
-  adj := parseInput("inp.txt")
+type solution struct{}
 
-  paths := findPaths(adj, 2)
-  fmt.Println("Paths", len(paths))
-  // for _, p := range paths {
-  //   fmt.Println(p.nodes)
-  // }
+func (solution) Parse(input string) AdjList {
+  return parseInput(input)
+}
+
+func (solution) Part1(adj AdjList) any {
+  return len(findPaths(adj, 1))
+}
+
+func (solution) Part2(adj AdjList) any {
+  return len(findPaths(adj, 2))
+}
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+  utils.Register[AdjList](2021, 12, solution{})
+  utils.RegisterExamples(2021, 12, examples)
 }
@@ -1,7 +1,7 @@
-package main
+package day13
 
 import (
-  "fmt"
+  "embed"
   "strconv"
   "strings"
 
@@ -17,8 +17,13 @@ type Fold struct {
 
 // solution
 
-func parseInput(filename string) (utils.PointSet, []Fold) {
-  lines := utils.ReadLines(filename)
+type Paper struct {
+  points utils.PointSet
+  folds []Fold
+}
+
+func parseInput(input string) Paper {
+  lines := utils.NonEmptyLines(input)
   points := utils.NewPointSet()
   var folds []Fold
   for _, l := range lines {
@@ -32,13 +37,13 @@ func parseInput(filename string) (utils.PointSet, []Fold) {
       points.Add(utils.Point{comps[0], comps[1]})
     }
   }
-  return points, folds
+  return Paper{points, folds}
 }
 
-func printGrid(ps utils.PointSet) {
+func renderGrid(ps utils.PointSet) string {
   maxX := 0
   maxY := 0
-  for p := range ps {
+  for _, p := range ps.Values() {
     maxX = utils.Max(p[0], maxX)
     maxY = utils.Max(p[1], maxY)
   }
@@ -51,7 +56,7 @@ func printGrid(ps utils.PointSet) {
     }
   }
 
-  for p := range ps {
+  for _, p := range ps.Values() {
     grid[p[1]][p[0]] = "#"
   }
 
@@ -59,7 +64,7 @@ func printGrid(ps utils.PointSet) {
   for i, r := range grid {
     lines[i] = strings.Join(r, "")
   }
-  fmt.Println(strings.Join(lines, "\n"))
+  return strings.Join(lines, "\n")
 }
 
 func fold(ps utils.PointSet, f Fold) {
@@ -68,7 +73,7 @@ func fold(ps utils.PointSet, f Fold) {
     pi = 1
   }
 
-  for p := range ps {
+  for _, p := range ps.Values() {
     val := p[pi]
     if val < f.index {
       continue
@@ -80,13 +85,29 @@ func fold(ps utils.PointSet, f Fold) {
   }
 }
 
-func main() {
-  points, folds := parseInput("inp.txt")
-  for i, f := range folds {
-    fold(points, f)
-    if i == 0 {
-      fmt.Println("after 1", len(points))
-    }
+type solution struct{}
+
+func (solution) Parse(input string) Paper {
+  return parseInput(input)
+}
+
+func (solution) Part1(paper Paper) any {
+  fold(paper.points, paper.folds[0])
+  return paper.points.Size()
+}
+
+// The answer is the code drawn by the points
+func (solution) Part2(paper Paper) any {
+  for _, f := range paper.folds {
+    fold(paper.points, f)
   }
-  printGrid(points)
+  return renderGrid(paper.points)
+}
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+  utils.Register[Paper](2021, 13, solution{})
+  utils.RegisterExamples(2021, 13, examples)
 }
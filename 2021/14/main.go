@@ -1,92 +1,59 @@
-package main
+package day14
 
 import (
-  "fmt"
-  "math"
+  "embed"
   "strings"
-  "time"
 
   "aoc/utils"
 )
 
 type Polymer struct {
-  elemCounts map[string]int
-  pairCounts map[string]int
+  pairs *utils.StateCounter[string]
+  // every element starts a pair but the last one
+  last byte
 }
 type Rules map[string]byte
-type SCount struct {
-  token string
-  count int
-}
 
-func incCount(m map[string]int, v string, inc int) int {
-  _, ok := m[v]
-  if ok {
-    m[v] += inc
-  } else {
-    m[v] = inc
+func NewPolymer(template string) *Polymer {
+  p := &Polymer{utils.NewStateCounter[string](), template[len(template)-1]}
+  for i := 0; i < len(template)-1; i++ {
+    p.pairs.Counts.Add(template[i:i+2], 1)
   }
-  return m[v]
-}
-
-
-func NewPolymer() *Polymer {
-  p := &Polymer{}
-  p.elemCounts = make(map[string]int)
-  p.pairCounts = make(map[string]int)
   return p
 }
 
-func (s *Polymer) MinMaxElem() (SCount, SCount) {
-  max := SCount{}
-  min := math.Inf(1)
-  var minS string
-  for s, c := range s.elemCounts {
-    max.count = utils.Max(max.count, c)
-    if max.count == c {
-      max.token = s
-    }
-    min = math.Min(float64(c), min)
-    if float64(c) == min {
-      minS = s
-    }
+func (s *Polymer) ElemCounts() utils.Counter[byte] {
+  elems := utils.NewCounter(s.last)
+  for p, c := range s.pairs.Counts {
+    elems.Add(p[0], c)
   }
-
-  return SCount{minS, int(min)}, max
+  return elems
 }
 
-
 func (s *Polymer) Step(rules Rules) {
-  items := make([]SCount, len(s.pairCounts))
-  i := 0
-  for p, c := range s.pairCounts {
-    items[i] = SCount{p, c}
-    i++
-  }
-
-  for _, sc := range items {
-    p, c := sc.token, sc.count
+  s.pairs.Step(func(p string, emit func(string)) {
     ins, ok := rules[p]
-    if ok {
-      incCount(s.elemCounts, string(ins), c)
-      if s.pairCounts[p] <= c {
-        delete(s.pairCounts, p)
-      } else {
-        s.pairCounts[p] -= c
-      }
-      incCount(s.pairCounts, string([]byte{p[0], ins}), c)
-      incCount(s.pairCounts, string([]byte{ins, p[1]}), c)
+    if !ok {
+      emit(p)
+      return
     }
-  }
+    emit(string([]byte{p[0], ins}))
+    emit(string([]byte{ins, p[1]}))
+  })
 }
 
 func (s *Polymer) GetAnswer() int {
-  min, max := s.MinMaxElem()
-  return max.count - min.count
+  counts := s.ElemCounts().MostCommon(-1)
+  return counts[0].N - counts[len(counts)-1].N
+}
+
+type Puzzle struct {
+  polymer *Polymer
+  rules Rules
 }
 
-func parseInput(filename string) (*Polymer, Rules) {
-  lines := utils.ReadLines(filename)
+func parseInput(input string) Puzzle {
+  lines := utils.NonEmptyLines(input)
 
   rules := make(Rules)
   for _, l := range lines[1:] {
@@ -94,31 +61,35 @@ func parseInput(filename string) (*Polymer, Rules) {
     rules[tokens[0]] = tokens[1][0]
   }
 
-  p := NewPolymer()
-  pline := lines[0]
-  for i := 0; i < len(pline); i++ {
-    incCount(p.elemCounts, string(pline[i]), 1)
+  p := NewPolymer(lines[0])
+  return Puzzle{p, rules}
+}
 
-    // cound pair
-    if i < len(pline)-1 {
-      pair := pline[i:i+2]
-      incCount(p.pairCounts, pair, 1)
-    }
+func solve(puzz Puzzle, steps int) int {
+  for i := 0; i < steps; i++ {
+    puzz.polymer.Step(puzz.rules)
   }
-  return p, rules
+  return puzz.polymer.GetAnswer()
 }
 
-func main() {
-  start := time.Now()
-  polymer, rules := parseInput("inp.txt")
-  p1 := 0
-  for i := 0; i < 40; i++ {
-    polymer.Step(rules)
-    if i == 9 {
-      p1 = polymer.GetAnswer()
-    }
-  }
-  fmt.Println("p1", p1, "p2", polymer.GetAnswer())
-  end := time.Now()
-  fmt.Println(end.Sub(start))
+type solution struct{}
+
+func (solution) Parse(input string) Puzzle {
+  return parseInput(input)
+}
+
+func (solution) Part1(puzz Puzzle) any {
+  return solve(puzz, 10)
+}
+
+func (solution) Part2(puzz Puzzle) any {
+  return solve(puzz, 40)
+}
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+  utils.Register[Puzzle](2021, 14, solution{})
+  utils.RegisterExamples(2021, 14, examples)
 }
@@ -1,13 +1,29 @@
-package main
+package day15
 
 import (
   "testing"
 
   "aoc/utils"
+  "aoc/utils/aoctest"
 )
 
 func BenchmarkPath(b *testing.B) {
-  grid := utils.ParseIntGrid(utils.ReadLines("inp.txt"), "")
-  big := makePart2Grid(grid)
-  bestPath(big)
+  loc, err := utils.NewInputLocator("")
+  if err != nil {
+    b.Fatal(err)
+  }
+  input, err := loc.Read(2021, 15, utils.INPUT_VARIANT)
+  if err != nil {
+    b.Skip(err)
+  }
+  big := makePart2Grid(utils.ParseIntGrid(utils.NonEmptyLines(input), ""))
+
+  b.ResetTimer()
+  for i := 0; i < b.N; i++ {
+    bestPath(big)
+  }
+}
+
+func TestExamples(t *testing.T) {
+  aoctest.TestExamples(t, 2021, 15)
 }
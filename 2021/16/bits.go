@@ -0,0 +1,67 @@
+package day16
+
+// Reads big-endian bit fields from bytes
+type bitReader struct {
+	data []byte
+	// position in bits
+	pos int
+}
+
+func (self *bitReader) remaining() int {
+	return len(self.data)*8 - self.pos
+}
+
+// Read the next n <= 64 bits as an unsigned int
+func (self *bitReader) read(n int) (uint64, error) {
+	if n > self.remaining() {
+		return 0, &DecodeError{self.pos, ErrTruncated}
+	}
+	var v uint64
+	for i := 0; i < n; i++ {
+		bit := self.data[self.pos/8] >> (7 - self.pos%8) & 1
+		v = v<<1 | uint64(bit)
+		self.pos++
+	}
+	return v, nil
+}
+
+// Whether every bit left is 0, as padding is
+func (self *bitReader) restZero() bool {
+	for self.remaining() > 0 {
+		if v, _ := self.read(1); v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Writes big-endian bit fields to bytes
+type bitWriter struct {
+	data []byte
+	// length in bits
+	n int
+}
+
+// Write the low n <= 64 bits of v
+func (self *bitWriter) write(v uint64, n int) {
+	for i := n - 1; i >= 0; i-- {
+		if self.n%8 == 0 {
+			self.data = append(self.data, 0)
+		}
+		self.data[self.n/8] |= byte(v>>i&1) << (7 - self.n%8)
+		self.n++
+	}
+}
+
+// Append everything written to other
+func (self *bitWriter) writeBits(other *bitWriter) {
+	r := bitReader{data: other.data}
+	for r.pos < other.n {
+		chunk := other.n - r.pos
+		if chunk > 64 {
+			chunk = 64
+		}
+		v, _ := r.read(chunk)
+		self.write(v, chunk)
+	}
+}
@@ -0,0 +1,210 @@
+package day16
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	LITERAL_ID = 4
+	// operator length types: sub-packets given by their length in bits, or
+	// by their count
+	LENGTH_BITS  = 0
+	LENGTH_COUNT = 1
+)
+
+var (
+	ErrBadHex = errors.New("not hex")
+	// the input ends in the middle of a packet
+	ErrTruncated = errors.New("truncated packet")
+	// a literal doesn't fit in 64 bits
+	ErrLiteralOverflow = errors.New("literal overflows 64 bits")
+	// sub-packets run past the bit length of their operator
+	ErrBadLength = errors.New("sub-packets overrun their length")
+	// bits that aren't zero padding after the outermost packet
+	ErrTrailingData = errors.New("data after the packet")
+	// a packet has a field too big for its width when encoding
+	ErrFieldOverflow = errors.New("field too big to encode")
+)
+
+// Where and why decoding failed
+type DecodeError struct {
+	// position in bits
+	Bit int
+	Err error
+}
+
+func (self *DecodeError) Error() string {
+	return fmt.Sprintf("bit %d: %v", self.Bit, self.Err)
+}
+
+func (self *DecodeError) Unwrap() error {
+	return self.Err
+}
+
+// Decode the packet a hex transmission holds
+func decodeHex(s string) (packet, error) {
+	s = strings.TrimSpace(s)
+	if len(s)%2 == 1 {
+		s += "0"
+	}
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		bit := 0
+		var invalid hex.InvalidByteError
+		if errors.As(err, &invalid) {
+			bit = 4 * strings.IndexByte(s, byte(invalid))
+		}
+		return packet{}, &DecodeError{bit, ErrBadHex}
+	}
+	return decode(data)
+}
+
+// Decode the packet data holds, followed by nothing but zero padding
+func decode(data []byte) (packet, error) {
+	r := bitReader{data: data}
+	pck, err := decodePacket(&r)
+	if err != nil {
+		return packet{}, err
+	}
+	end := r.pos
+	if !r.restZero() {
+		return packet{}, &DecodeError{end, ErrTrailingData}
+	}
+	return pck, nil
+}
+
+func decodePacket(r *bitReader) (packet, error) {
+	var pck packet
+	var err error
+	if pck.version, err = r.read(3); err != nil {
+		return pck, err
+	}
+	if pck.id, err = r.read(3); err != nil {
+		return pck, err
+	}
+
+	if pck.id == LITERAL_ID {
+		// groups of 4 bits, each after a bit saying if more follow
+		for more := uint64(1); more == 1; {
+			start := r.pos
+			if more, err = r.read(1); err != nil {
+				return pck, err
+			}
+			group, err := r.read(4)
+			if err != nil {
+				return pck, err
+			}
+			if pck.value>>60 != 0 {
+				return pck, &DecodeError{start, ErrLiteralOverflow}
+			}
+			pck.value = pck.value<<4 | group
+		}
+		return pck, nil
+	}
+
+	lengthType, err := r.read(1)
+	if err != nil {
+		return pck, err
+	}
+	pck.lengthType = uint8(lengthType)
+
+	if lengthType == LENGTH_BITS {
+		length, err := r.read(15)
+		if err != nil {
+			return pck, err
+		}
+		end := r.pos + int(length)
+		if end > len(r.data)*8 {
+			return pck, &DecodeError{r.pos, ErrTruncated}
+		}
+		for r.pos < end {
+			start := r.pos
+			sub, err := decodePacket(r)
+			if err != nil {
+				return pck, err
+			}
+			if r.pos > end {
+				return pck, &DecodeError{start, ErrBadLength}
+			}
+			pck.sub = append(pck.sub, sub)
+		}
+		return pck, nil
+	}
+
+	count, err := r.read(11)
+	if err != nil {
+		return pck, err
+	}
+	for i := uint64(0); i < count; i++ {
+		sub, err := decodePacket(r)
+		if err != nil {
+			return pck, err
+		}
+		pck.sub = append(pck.sub, sub)
+	}
+	return pck, nil
+}
+
+// Encode a packet as upper case hex, zero padded to whole bytes
+func encodeHex(pck packet) (string, error) {
+	var w bitWriter
+	if err := encodePacket(&w, pck); err != nil {
+		return "", err
+	}
+	return strings.ToUpper(hex.EncodeToString(w.data)), nil
+}
+
+func encodePacket(w *bitWriter, pck packet) error {
+	if pck.version >= 1<<3 || pck.id >= 1<<3 {
+		return fmt.Errorf("version %d id %d: %w", pck.version, pck.id, ErrFieldOverflow)
+	}
+	w.write(pck.version, 3)
+	w.write(pck.id, 3)
+
+	if pck.id == LITERAL_ID {
+		groups := 1
+		for pck.value>>(4*groups) != 0 && groups < 16 {
+			groups++
+		}
+		for g := groups - 1; g >= 0; g-- {
+			more := uint64(0)
+			if g > 0 {
+				more = 1
+			}
+			w.write(more, 1)
+			w.write(pck.value>>(4*g), 4)
+		}
+		return nil
+	}
+
+	if pck.lengthType == LENGTH_COUNT {
+		if len(pck.sub) >= 1<<11 {
+			return fmt.Errorf("%d sub-packets: %w", len(pck.sub), ErrFieldOverflow)
+		}
+		w.write(LENGTH_COUNT, 1)
+		w.write(uint64(len(pck.sub)), 11)
+		for _, sub := range pck.sub {
+			if err := encodePacket(w, sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var subs bitWriter
+	for _, sub := range pck.sub {
+		if err := encodePacket(&subs, sub); err != nil {
+			return err
+		}
+	}
+	if subs.n >= 1<<15 {
+		return fmt.Errorf("sub-packets of %d bits: %w", subs.n, ErrFieldOverflow)
+	}
+	w.write(LENGTH_BITS, 1)
+	w.write(uint64(subs.n), 15)
+	w.writeBits(&subs)
+	return nil
+}
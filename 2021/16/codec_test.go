@@ -0,0 +1,102 @@
+package day16
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	for _, h := range []string{
+		"D2FE28",
+		"38006F45291200",
+		"EE00D40C823060",
+		"8A004A801A8002F478",
+		"620080001611562C8802118E34",
+		"C0015000016115A2E0802F182340",
+		"A0016C880162017C3686B18A3D4780",
+		"9C0141080250320F1802104A08",
+	} {
+		pck, err := decodeHex(h)
+		if err != nil {
+			t.Fatalf("(%s) %v", h, err)
+		}
+		enc, err := encodeHex(pck)
+		if err != nil {
+			t.Fatalf("(%s) %v", h, err)
+		}
+		dec, err := decodeHex(enc)
+		if err != nil {
+			t.Fatalf("(%s) decoding %s: %v", h, enc, err)
+		}
+		if !reflect.DeepEqual(pck, dec) || sexpr(pck) != sexpr(dec) {
+			t.Fatalf("(%s) expected %s got %s from %s", h, sexpr(pck), sexpr(dec), enc)
+		}
+	}
+
+	// these have no extra padding, so they come back bit for bit
+	for _, h := range []string{"D2FE28", "38006F45291200", "EE00D40C823060"} {
+		pck, _ := decodeHex(h)
+		if enc, _ := encodeHex(pck); enc != h {
+			t.Fatalf("encodeHex expected %s got %s", h, enc)
+		}
+	}
+}
+
+func TestSexpr(t *testing.T) {
+	for h, expected := range map[string]string{
+		"D2FE28":                     "2021",
+		"C200B40A82":                 "(sum 1 2)",
+		"9C0141080250320F1802104A08": "(eq (sum 1 3) (prod 2 2))",
+		"EE00D40C823060":             "(max 1 2 3)",
+	} {
+		pck, err := decodeHex(h)
+		if err != nil {
+			t.Fatalf("(%s) %v", h, err)
+		}
+		if s := sexpr(pck); s != expected {
+			t.Fatalf("(%s) expected %s got %s", h, expected, s)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	// a literal of 17 groups
+	var long bitWriter
+	long.write(LITERAL_ID, 6)
+	for i := 0; i < 16; i++ {
+		long.write(0b11111, 5)
+	}
+	long.write(0, 5)
+
+	// an operator of 10 bits holding an 11 bit literal
+	var overrun bitWriter
+	overrun.write(0, 6)
+	overrun.write(LENGTH_BITS, 1)
+	overrun.write(10, 15)
+	overrun.write(LITERAL_ID, 6)
+	overrun.write(1, 5)
+
+	for _, tc := range []struct {
+		name string
+		data func() (packet, error)
+		err  error
+		bit  int
+	}{
+		{"truncated", func() (packet, error) { return decodeHex("D2FE") }, ErrTruncated, 16},
+		{"bad hex", func() (packet, error) { return decodeHex("D2FG28") }, ErrBadHex, 12},
+		{"trailing", func() (packet, error) { return decodeHex("D2FE28FF") }, ErrTrailingData, 21},
+		{"long literal", func() (packet, error) { return decode(long.data) }, ErrLiteralOverflow, 86},
+		{"overrun", func() (packet, error) { return decode(overrun.data) }, ErrBadLength, 22},
+	} {
+		_, err := tc.data()
+		var de *DecodeError
+		if !errors.Is(err, tc.err) || !errors.As(err, &de) || de.Bit != tc.bit {
+			t.Fatalf("%s expected %v at bit %d got %v", tc.name, tc.err, tc.bit, err)
+		}
+	}
+
+	if _, err := encodeHex(packet{version: 8, id: LITERAL_ID}); !errors.Is(err, ErrFieldOverflow) {
+		t.Fatalf("encodeHex of version 8 expected %v got %v", ErrFieldOverflow, err)
+	}
+}
@@ -0,0 +1,141 @@
+package day16
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var ErrSyntax = errors.New("syntax error")
+
+// Where and why compiling failed
+type CompileError struct {
+	// byte offset in the source
+	Pos int
+	Err error
+}
+
+func (self *CompileError) Error() string {
+	return fmt.Sprintf("offset %d: %v", self.Pos, self.Err)
+}
+
+func (self *CompileError) Unwrap() error {
+	return self.Err
+}
+
+type token struct {
+	pos  int
+	text string
+}
+
+// Split an expression into parens and atoms
+func tokenize(src string) []token {
+	var toks []token
+	for i := 0; i < len(src); {
+		switch c := src[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(' || c == ')':
+			toks = append(toks, token{i, src[i : i+1]})
+			i++
+		default:
+			start := i
+			for i < len(src) && !isDelim(src[i]) {
+				i++
+			}
+			toks = append(toks, token{start, src[start:i]})
+		}
+	}
+	return toks
+}
+
+func isDelim(c byte) bool {
+	switch c {
+	case ' ', '\t', '\n', '\r', '(', ')':
+		return true
+	}
+	return false
+}
+
+// Compile an expression like (sum 1 (prod 2 3)) to the packet evaluating it
+func compile(src string) (packet, error) {
+	ids := make(map[string]uint64, len(ops))
+	for id, o := range ops {
+		ids[o.name] = id
+	}
+
+	p := parser{toks: tokenize(src), end: len(src), ids: ids}
+	pck, err := p.expr()
+	if err != nil {
+		return packet{}, err
+	}
+	if p.i < len(p.toks) {
+		return packet{}, p.errorf("unexpected %q after the expression", p.toks[p.i].text)
+	}
+	return pck, nil
+}
+
+type parser struct {
+	toks []token
+	i    int
+	// length of the source, where errors at the end are
+	end int
+	ids map[string]uint64
+}
+
+func (self *parser) errorf(format string, args ...any) error {
+	pos := self.end
+	if self.i < len(self.toks) {
+		pos = self.toks[self.i].pos
+	}
+	return &CompileError{pos, fmt.Errorf("%w: "+format, append([]any{ErrSyntax}, args...)...)}
+}
+
+func (self *parser) expr() (packet, error) {
+	if self.i == len(self.toks) {
+		return packet{}, self.errorf("unexpected end")
+	}
+	tok := self.toks[self.i]
+
+	switch tok.text {
+	case ")":
+		return packet{}, self.errorf("unexpected )")
+	case "(":
+	default:
+		n, err := strconv.ParseUint(tok.text, 10, 64)
+		if err != nil {
+			return packet{}, self.errorf("bad literal %q", tok.text)
+		}
+		self.i++
+		return packet{id: LITERAL_ID, value: n}, nil
+	}
+
+	self.i++
+	if self.i == len(self.toks) || self.toks[self.i].text == "(" || self.toks[self.i].text == ")" {
+		return packet{}, self.errorf("expected an operator")
+	}
+	name := self.toks[self.i]
+	id, ok := self.ids[name.text]
+	if !ok {
+		return packet{}, &CompileError{name.pos, fmt.Errorf("%q: %w", name.text, ErrUnknownOp)}
+	}
+	self.i++
+
+	pck := packet{id: id, lengthType: LENGTH_BITS}
+	for self.i < len(self.toks) && self.toks[self.i].text != ")" {
+		sub, err := self.expr()
+		if err != nil {
+			return packet{}, err
+		}
+		pck.sub = append(pck.sub, sub)
+	}
+	if self.i == len(self.toks) {
+		return packet{}, self.errorf("missing )")
+	}
+	self.i++
+
+	if err := ops[id].checkArity(len(pck.sub)); err != nil {
+		return packet{}, &CompileError{tok.pos, err}
+	}
+	return pck, nil
+}
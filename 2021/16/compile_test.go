@@ -0,0 +1,139 @@
+package day16
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	for src, expected := range map[string]string{
+		"(sum 1 (prod 2 3))":                                             "7",
+		"  ( max 1\n2 3 ) ":                                              "3",
+		"(eq (sum 1 3) (prod 2 2))":                                      "1",
+		"(lt (min 5 4) 4)":                                               "0",
+		"(sum 18446744073709551615 1)":                                   "18446744073709551616",
+		"(prod 18446744073709551615 2)":                                  "36893488147419103230",
+		"(gt (prod 18446744073709551615 2) 5)":                           "1",
+		"(min (sum 18446744073709551615 1) 7)":                           "7",
+		"(sum (prod 4294967296 4294967296) 0)":                           "18446744073709551616",
+		"(eq (sum 18446744073709551615 1) (prod 4294967296 4294967296))": "1",
+	} {
+		pck, err := compile(src)
+		if err != nil {
+			t.Fatalf("(%s) %v", src, err)
+		}
+		v, err := evaluate(pck)
+		if err != nil {
+			t.Fatalf("(%s) %v", src, err)
+		}
+		if v.String() != expected {
+			t.Fatalf("(%s) expected %s got %s", src, expected, v)
+		}
+	}
+
+	// back to a uint64 once the big value fits again
+	pck, _ := compile("(min (sum 18446744073709551615 1) 7)")
+	if v, _ := evaluate(pck); v.isBig() || v.answer() != uint64(7) {
+		t.Fatalf("min expected uint64 7 got %v", v.answer())
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, tc := range []struct {
+		src string
+		err error
+		pos int
+	}{
+		{"(foo 1 2)", ErrUnknownOp, 1},
+		{"(gt 1)", ErrArity, 0},
+		{"(sum 1 2", ErrSyntax, 8},
+		{"(sum 1 2))", ErrSyntax, 9},
+		{"(sum -1)", ErrSyntax, 5},
+		{"(sum 18446744073709551616)", ErrSyntax, 5},
+		{"((sum 1))", ErrSyntax, 1},
+		{"", ErrSyntax, 0},
+	} {
+		_, err := compile(tc.src)
+		var ce *CompileError
+		if !errors.Is(err, tc.err) || !errors.As(err, &ce) || ce.Pos != tc.pos {
+			t.Fatalf("(%s) expected %v at %d got %v", tc.src, tc.err, tc.pos, err)
+		}
+	}
+
+	if _, err := evaluate(packet{id: 9}); !errors.Is(err, ErrUnknownOp) {
+		t.Fatalf("type 9 expected %v got %v", ErrUnknownOp, err)
+	}
+	if _, err := evaluate(packet{id: 5, sub: []packet{{id: LITERAL_ID}}}); !errors.Is(err, ErrArity) {
+		t.Fatalf("gt of 1 expected %v got %v", ErrArity, err)
+	}
+}
+
+// A random expression of at most the given depth
+func randExpr(rng *rand.Rand, depth int) string {
+	if depth == 0 || rng.Intn(3) == 0 {
+		if rng.Intn(4) == 0 {
+			return fmt.Sprint(rng.Uint64())
+		}
+		return fmt.Sprint(rng.Intn(100))
+	}
+
+	o := ops[[]uint64{0, 1, 2, 3, 5, 6, 7}[rng.Intn(7)]]
+	n := o.minArgs
+	if o.maxArgs < 0 {
+		n += rng.Intn(4)
+	}
+	args := []string{o.name}
+	for i := 0; i < n; i++ {
+		args = append(args, randExpr(rng, depth-1))
+	}
+	return "(" + strings.Join(args, " ") + ")"
+}
+
+func TestRandomRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(16))
+	for i := 0; i < 500; i++ {
+		src := randExpr(rng, 5)
+		pck, err := compile(src)
+		if err != nil {
+			t.Fatalf("(%s) %v", src, err)
+		}
+		if s := sexpr(pck); s != src {
+			t.Fatalf("(%s) sexpr expected %s got %s", src, src, s)
+		}
+
+		// through the codec with either length type
+		if rng.Intn(2) == 0 {
+			var setCount func(p *packet)
+			setCount = func(p *packet) {
+				if p.id != LITERAL_ID {
+					p.lengthType = LENGTH_COUNT
+				}
+				for i := range p.sub {
+					setCount(&p.sub[i])
+				}
+			}
+			setCount(&pck)
+		}
+		h, err := encodeHex(pck)
+		if err != nil {
+			t.Fatalf("(%s) %v", src, err)
+		}
+		dec, err := decodeHex(h)
+		if err != nil {
+			t.Fatalf("(%s) decoding %s: %v", src, h, err)
+		}
+		if !reflect.DeepEqual(pck, dec) {
+			t.Fatalf("(%s) expected %s got %s from %s", src, sexpr(pck), sexpr(dec), h)
+		}
+
+		want, _ := evaluate(pck)
+		got, err := evaluate(dec)
+		if err != nil || got.cmp(want) != 0 {
+			t.Fatalf("(%s) expected %v got %v %v", src, want, got, err)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package day16
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+var (
+	// a packet type with no operator
+	ErrUnknownOp = errors.New("unknown operator")
+	// an operator with the wrong number of sub-packets
+	ErrArity = errors.New("wrong number of operands")
+)
+
+// The value of an expression: a uint64 until it overflows, then a big.Int
+type value struct {
+	n   uint64
+	big *big.Int
+}
+
+func (self value) isBig() bool {
+	return self.big != nil
+}
+
+func (self value) toBig() *big.Int {
+	if self.isBig() {
+		return self.big
+	}
+	return new(big.Int).SetUint64(self.n)
+}
+
+// Back to a uint64 if the big value fits in one
+func bigValue(b *big.Int) value {
+	if b.IsUint64() {
+		return value{n: b.Uint64()}
+	}
+	return value{big: b}
+}
+
+func (self value) cmp(other value) int {
+	if self.isBig() || other.isBig() {
+		return self.toBig().Cmp(other.toBig())
+	}
+	switch {
+	case self.n < other.n:
+		return -1
+	case self.n > other.n:
+		return 1
+	}
+	return 0
+}
+
+func (self value) String() string {
+	return self.toBig().String()
+}
+
+// The value as the answer: a uint64, or a *big.Int if it doesn't fit
+func (self value) answer() any {
+	if self.isBig() {
+		return self.big
+	}
+	return self.n
+}
+
+func add(a, b value) value {
+	if !a.isBig() && !b.isBig() {
+		if s, carry := bits.Add64(a.n, b.n, 0); carry == 0 {
+			return value{n: s}
+		}
+	}
+	return bigValue(new(big.Int).Add(a.toBig(), b.toBig()))
+}
+
+func mul(a, b value) value {
+	if !a.isBig() && !b.isBig() {
+		if hi, lo := bits.Mul64(a.n, b.n); hi == 0 {
+			return value{n: lo}
+		}
+	}
+	return bigValue(new(big.Int).Mul(a.toBig(), b.toBig()))
+}
+
+func boolValue(b bool) value {
+	if b {
+		return value{n: 1}
+	}
+	return value{}
+}
+
+type op struct {
+	name string
+	// sub-packets the operator takes, -1 for any number
+	minArgs, maxArgs int
+	fn               func(args []value) value
+}
+
+// Check the operator can take n sub-packets
+func (self op) checkArity(n int) error {
+	if n < self.minArgs || (self.maxArgs >= 0 && n > self.maxArgs) {
+		return fmt.Errorf("%s of %d: %w", self.name, n, ErrArity)
+	}
+	return nil
+}
+
+func fold(f func(a, b value) value) func([]value) value {
+	return func(args []value) value {
+		acc := args[0]
+		for _, v := range args[1:] {
+			acc = f(acc, v)
+		}
+		return acc
+	}
+}
+
+func compare(pred func(c int) bool) func([]value) value {
+	return func(args []value) value {
+		return boolValue(pred(args[0].cmp(args[1])))
+	}
+}
+
+// Operators by packet type id
+var ops = map[uint64]op{
+	0: {"sum", 1, -1, fold(add)},
+	1: {"prod", 1, -1, fold(mul)},
+	2: {"min", 1, -1, fold(func(a, b value) value {
+		if b.cmp(a) < 0 {
+			return b
+		}
+		return a
+	})},
+	3: {"max", 1, -1, fold(func(a, b value) value {
+		if b.cmp(a) > 0 {
+			return b
+		}
+		return a
+	})},
+	5: {"gt", 2, 2, compare(func(c int) bool { return c > 0 })},
+	6: {"lt", 2, 2, compare(func(c int) bool { return c < 0 })},
+	7: {"eq", 2, 2, compare(func(c int) bool { return c == 0 })},
+}
+
+// Evaluate the expression a packet encodes
+func evaluate(pck packet) (value, error) {
+	if pck.id == LITERAL_ID {
+		return value{n: pck.value}, nil
+	}
+	o, ok := ops[pck.id]
+	if !ok {
+		return value{}, fmt.Errorf("type %d: %w", pck.id, ErrUnknownOp)
+	}
+	if err := o.checkArity(len(pck.sub)); err != nil {
+		return value{}, err
+	}
+
+	args := make([]value, len(pck.sub))
+	for i, sp := range pck.sub {
+		v, err := evaluate(sp)
+		if err != nil {
+			return value{}, err
+		}
+		args[i] = v
+	}
+	return o.fn(args), nil
+}
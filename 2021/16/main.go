@@ -1,6 +1,7 @@
-package main
+package day16
 
 import (
+	"embed"
 	"fmt"
 	"strconv"
 	"strings"
@@ -13,90 +14,9 @@ type packet struct {
 	id      uint64
 	value   uint64
 	sub     []packet
-}
-
-func binToDec(bs string) uint64 {
-	i, _ := strconv.ParseUint(bs, 2, 64)
-	return i
-}
-
-func hexTo4Bin(s string) string {
-	b := strings.Builder{}
-
-	for _, c := range s {
-		i, _ := strconv.ParseUint(string(c), 16, 8)
-		bs := strconv.FormatUint(i, 2)
-		if len(bs) < 4 {
-			for i := 0; i < 4-len(bs); i++ {
-				b.WriteByte('0')
-			}
-		}
-		b.WriteString(bs)
-	}
-	return b.String()
-}
-
-func parseLiteral(s string) (uint64, int) {
-	b := strings.Builder{}
-	i := 0
-	for {
-		first := s[i]
-		b.WriteString(s[i+1 : i+5])
-		i += 5
-		if first == '0' {
-			break
-		}
-	}
-	return binToDec(b.String()), i
-}
-
-func parse(raw string, n int) ([]packet, int) {
-	consumed_bits := 0
-	var pcks []packet
-
-	for len(raw) >= 7 && (n < 0 || n > 0) {
-		var result packet
-		result.version = binToDec(raw[:3])
-		n--
-		result.id = binToDec(raw[3:6])
-		remain := raw[6:]
-		used := 6
-		allZero := true
-		for _, b := range remain {
-			allZero = allZero && b == '0'
-		}
-		if allZero {
-			break
-		}
-
-		if result.id == 4 {
-			v, u := parseLiteral(remain)
-			used += u
-			result.value = v
-		} else {
-			used += 1
-			// length type
-			if remain[0] == '0' {
-				l := int(binToDec(remain[1:16]))
-				used += l + 15
-				remain = remain[16:]
-				sub, _ := parse(remain[:l], -1)
-				result.sub = sub
-			} else {
-				l := binToDec(remain[1:12])
-				remain := remain[12:]
-				used += 11
-				sub, bits := parse(remain, int(l))
-				used += bits
-				result.sub = sub
-			}
-		}
-
-		raw = raw[used:]
-		consumed_bits += used
-		pcks = append(pcks, result)
-	}
-	return pcks, consumed_bits
+	// how an operator's sub-packets were delimited, kept so encoding gives
+	// back the same bits
+	lengthType uint8
 }
 
 func sumVersion(pck packet) uint64 {
@@ -107,75 +27,51 @@ func sumVersion(pck packet) uint64 {
 	return tot
 }
 
-func evaluate(pck packet) uint64 {
-	switch pck.id {
-	case 0:
-		// sum
-		t := uint64(0)
-		for _, sp := range pck.sub {
-			t += evaluate(sp)
-		}
-		return t
-
-	case 1:
-		// prod
-		t := uint64(1)
-		for _, sp := range pck.sub {
-			t *= evaluate(sp)
-		}
-		return t
-
-	case 2:
-		// min
-		t := ^uint64(0)
-		for _, sp := range pck.sub {
-			t = utils.Min(t, evaluate(sp))
-		}
-		return t
-
-	case 3:
-		// max
-		t := uint64(0)
-		for _, sp := range pck.sub {
-			t = utils.Max(t, evaluate(sp))
-		}
-		return t
+// The expression a packet evaluates, like (sum 1 (prod 2 3))
+func sexpr(pck packet) string {
+	if pck.id == LITERAL_ID {
+		return strconv.FormatUint(pck.value, 10)
+	}
+	name := fmt.Sprintf("op%d", pck.id)
+	if o, ok := ops[pck.id]; ok {
+		name = o.name
+	}
 
-	case 5:
-		// gt
-		if evaluate(pck.sub[0]) > evaluate(pck.sub[1]) {
-			return 1
-		}
-		return 0
+	var bld strings.Builder
+	bld.WriteString("(" + name)
+	for _, sp := range pck.sub {
+		bld.WriteString(" " + sexpr(sp))
+	}
+	bld.WriteString(")")
+	return bld.String()
+}
 
-	case 6:
-		// lt
-		if evaluate(pck.sub[0]) < evaluate(pck.sub[1]) {
-			return 1
-		}
-		return 0
+type solution struct{}
 
-	case 7:
-		// eq
-		if evaluate(pck.sub[0]) == evaluate(pck.sub[1]) {
-			return 1
-		}
-		return 0
+func (solution) Parse(input string) packet {
+	pck, err := decodeHex(utils.NonEmptyLines(input)[0])
+	if err != nil {
+		panic(err)
+	}
+	return pck
+}
 
-	case 4:
-		// literal
-		return pck.value
+func (solution) Part1(pck packet) any {
+	return sumVersion(pck)
+}
 
-	default:
-		panic("Wut")
+func (solution) Part2(pck packet) any {
+	v, err := evaluate(pck)
+	if err != nil {
+		panic(err)
 	}
+	return v.answer()
 }
 
-func main() {
-	pck := hexTo4Bin(utils.ReadLines("inp.txt")[0])
-	pcks, _ := parse(pck, -1)
-	pack := pcks[0]
+//go:embed examples
+var examples embed.FS
 
-	fmt.Println("p1:", sumVersion(pack))
-	fmt.Println("p2:", evaluate(pack))
+func init() {
+	utils.Register[packet](2021, 16, solution{})
+	utils.RegisterExamples(2021, 16, examples)
 }
@@ -1,7 +1,9 @@
-package main
+package day16
 
 import (
 	"testing"
+
+	"aoc/utils/aoctest"
 )
 
 func TestP1(t *testing.T) {
@@ -15,10 +17,12 @@ func TestP1(t *testing.T) {
 	}
 	sums := []uint64{16, 12, 23, 31, 9, 14}
 	for i, pck := range inps {
-		pck = hexTo4Bin(pck)
-		p, _ := parse(pck, -1)
+		p, err := decodeHex(pck)
+		if err != nil {
+			t.Fatalf("(%s) %v", pck, err)
+		}
 
-		v := sumVersion(p[0])
+		v := sumVersion(p)
 		if v != sums[i] {
 			t.Errorf("(%s) expected %d, got: %d", pck, sums[i], v)
 		}
@@ -41,11 +45,18 @@ func TestP2(t *testing.T) {
 	}
 
 	for i, pck := range inps {
-		p, _ := parse(hexTo4Bin(pck), -1)
+		p, err := decodeHex(pck)
+		if err != nil {
+			t.Fatalf("(%s) %v", pck, err)
+		}
 
-		v := evaluate(p[0])
-		if v != ans[i] {
-			t.Errorf("(%s) expected %d, got: %d (case %d)", pck, ans[i], v, i)
+		v, err := evaluate(p)
+		if err != nil || v.isBig() || v.n != ans[i] {
+			t.Errorf("(%s) expected %d, got: %v %v (case %d)", pck, ans[i], v, err, i)
 		}
 	}
 }
+
+func TestExamples(t *testing.T) {
+	aoctest.TestExamples(t, 2021, 16)
+}
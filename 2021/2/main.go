@@ -1,6 +1,7 @@
-package main
+package day2
 
 import (
+  "embed"
   "fmt"
   "strconv"
   "strings"
@@ -8,19 +9,17 @@ import (
   "aoc/utils"
 )
 
-func main() {
+func dive(commands []string, useAim bool) int {
   depth := 0
   pos := 0
   aim := 0
 
-  commands := utils.ReadLines("inp.txt")
 
   for _, com := range commands {
     tokens := strings.Split(com, " ")
 
     if len(tokens) != 2 {
-      fmt.Errorf("unexpected len: %d", len(tokens))
-      panic("wut")
+      panic(fmt.Sprintf("unexpected len: %d", len(tokens)))
     }
 
     n, err := strconv.Atoi(tokens[1])
@@ -40,6 +39,31 @@ func main() {
     }
   }
 
-  fmt.Println("Pos:", pos, "Depth:", depth)
-  fmt.Println("Mult:", depth * pos)
+  if !useAim {
+    // without aim, the aim is just the depth
+    depth = aim
+  }
+  return depth * pos
+}
+
+type solution struct{}
+
+func (solution) Parse(input string) []string {
+  return utils.NonEmptyLines(input)
+}
+
+func (solution) Part1(commands []string) any {
+  return dive(commands, false)
+}
+
+func (solution) Part2(commands []string) any {
+  return dive(commands, true)
+}
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+  utils.Register[[]string](2021, 2, solution{})
+  utils.RegisterExamples(2021, 2, examples)
 }
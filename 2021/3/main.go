@@ -1,7 +1,7 @@
-package main
+package day3
 
 import (
-  "fmt"
+  "embed"
   "math"
 
   "aoc/utils"
@@ -79,8 +79,13 @@ func findnum(nums []string, max bool) string {
 
 // parts
 
-func part1() {
-  lines := utils.ReadLines("inp.txt")
+type solution struct{}
+
+func (solution) Parse(input string) []string {
+  return utils.NonEmptyLines(input)
+}
+
+func (solution) Part1(lines []string) any {
   bits := len(lines[0])
 
   freq := onefreq(lines)
@@ -95,20 +100,20 @@ func part1() {
       epsilon += 1 * dec
     }
   }
-  fmt.Println("gamma:", gamma, "epsilon:", epsilon)
-  fmt.Println("mult:", gamma * epsilon)
+  return gamma * epsilon
 }
 
-func part2() {
-  lines := utils.ReadLines("inp.txt")
-
+func (solution) Part2(lines []string) any {
   oxygen := bintodec(findnum(lines, true))
   co2 := bintodec(findnum(lines, false))
 
-  fmt.Println("oxy:", oxygen, "co2:", co2)
-  fmt.Println("res:", oxygen * co2)
+  return oxygen * co2
 }
 
-func main() {
-  part2()
+//go:embed examples
+var examples embed.FS
+
+func init() {
+  utils.Register[[]string](2021, 3, solution{})
+  utils.RegisterExamples(2021, 3, examples)
 }
@@ -1,7 +1,7 @@
-package main
+package day4
 
 import (
-  "fmt"
+  "embed"
   "strings"
 
   "aoc/utils"
@@ -67,26 +67,29 @@ func unmarkedSquares(nums utils.IntSet, b *Board) []int {
 
 // parts
 
-func part1() {
-  lines := utils.ReadLines("inp.txt")
+type solution struct{}
 
+func (solution) Parse(input string) []string {
+  return utils.NonEmptyLines(input)
+}
+
+func (solution) Part1(lines []string) any {
   nums := utils.StrsToInts(strings.Split(lines[0], ","))
   curr_nums := utils.NewIntSet()
   boards := makeBoards(lines[1:])
 
   for _, n := range nums {
     curr_nums.Add(n)
-    for b, board := range boards {
+    for _, board := range boards {
       if boardDone(curr_nums, &board) {
-        fmt.Println("DONE:", b+1, "score:", n*utils.Sum(unmarkedSquares(curr_nums, &board)))
-        return
+        return n*utils.Sum(unmarkedSquares(curr_nums, &board))
       }
     }
   }
+  return nil
 }
 
-func part2() {
-  lines := utils.ReadLines("inp.txt")
+func (solution) Part2(lines []string) any {
   nums := utils.StrsToInts(strings.Split(lines[0], ","))
   curr_nums := utils.NewIntSet()
   boards := makeBoards(lines[1:])
@@ -96,12 +99,11 @@ func part2() {
     curr_nums.Add(n)
     var new_boards []Board
 
-    for b, board := range boards {
+    for _, board := range boards {
       if boardDone(curr_nums, &board) {
         remaning -= 1
         if remaning == 0 {
-          fmt.Println("DONE:", b+1, "score:", n*utils.Sum(unmarkedSquares(curr_nums, &board)))
-          return
+          return n*utils.Sum(unmarkedSquares(curr_nums, &board))
         }
       } else {
         new_boards = append(new_boards, board)
@@ -109,8 +111,13 @@ func part2() {
     }
     boards = new_boards
   }
+  return nil
 }
 
-func main() {
-  part2()
+//go:embed examples
+var examples embed.FS
+
+func init() {
+  utils.Register[[]string](2021, 4, solution{})
+  utils.RegisterExamples(2021, 4, examples)
 }
@@ -1,7 +1,7 @@
-package main
+package day5
 
 import (
-  "fmt"
+  "embed"
   "strings"
 
   "aoc/utils"
@@ -109,8 +109,7 @@ func makeBoard(ls []string, diag bool) ([][]int, []Line) {
   return board, lines
 }
 
-func solve(diag bool) {
-  ls := utils.ReadLines("inp.txt")
+func solve(ls []string, diag bool) int {
   board, _ := makeBoard(ls, diag)
   count := 0
   for _, r := range board {
@@ -123,17 +122,27 @@ func solve(diag bool) {
   // for _, r := range board {
   //  fmt.Println(r)
   // }
-  fmt.Println("count:", count)
+  return count
 }
 
-func part1() {
-  solve(false)
+type solution struct{}
+
+func (solution) Parse(input string) []string {
+  return utils.NonEmptyLines(input)
+}
+
+func (solution) Part1(ls []string) any {
+  return solve(ls, false)
 }
 
-func part2() {
-  solve(true)
+func (solution) Part2(ls []string) any {
+  return solve(ls, true)
 }
 
-func main() {
-  part2()
+//go:embed examples
+var examples embed.FS
+
+func init() {
+  utils.Register[[]string](2021, 5, solution{})
+  utils.RegisterExamples(2021, 5, examples)
 }
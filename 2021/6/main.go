@@ -1,41 +1,52 @@
-package main
+package day6
 
 import (
-  "fmt"
+  "embed"
   "strings"
 
   "aoc/utils"
 )
 
-type Cache map[[2]int]int
+func countFish(fish []int, days int) int {
+  // number of fish one fish with the given timer produces in the given days,
+  // itself included
+  fishFrom := utils.NewMemo(func(rec func([2]int) int, k [2]int) int {
+    age, days := k[0], k[1]
+    tot := 1
+    for days > age {
+      days -= age+1
+      age = 6
+      tot += rec([2]int{8, days})
+    }
+    return tot
+  })
 
-func calcfish(age int, days int, cache Cache) int {
-  v, ok := cache[[2]int{age, days}]
-  if ok {
-    return v
+  tot := 0
+  for _, f := range fish {
+    tot += fishFrom.Get([2]int{f, days})
   }
+  return tot
+}
 
-  tot := 1
-  for days > age {
-    days -= age+1
-    age = 6
-    res := calcfish(8, days, cache)
-    cache[[2]int{8, days}] = res
-    tot += res
-  }
+type solution struct{}
 
-  return tot
+func (solution) Parse(input string) []int {
+  content := utils.NonEmptyLines(input)[0]
+  return utils.StrsToInts(strings.Split(content, ","))
 }
 
-func main() {
-  content := utils.ReadLines("inp.txt")[0]
-  fish := utils.StrsToInts(strings.Split(content, ","))
+func (solution) Part1(fish []int) any {
+  return countFish(fish, 80)
+}
 
-  tot := 0
-  cache := Cache{}
-  for _, f := range fish {
-    tot += calcfish(f, 256, cache)
-  }
+func (solution) Part2(fish []int) any {
+  return countFish(fish, 256)
+}
+
+//go:embed examples
+var examples embed.FS
 
-  fmt.Println("count:", tot)
+func init() {
+  utils.Register[[]int](2021, 6, solution{})
+  utils.RegisterExamples(2021, 6, examples)
 }
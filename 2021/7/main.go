@@ -1,7 +1,7 @@
-package main
+package day7
 
 import (
-  "fmt"
+  "embed"
   "math"
   "sort"
   "strings"
@@ -9,7 +9,13 @@ import (
   "aoc/utils"
 )
 
-func part1(nums []int) {
+type solution struct{}
+
+func (solution) Parse(input string) []int {
+  return utils.StrsToInts(strings.Split(utils.NonEmptyLines(input)[0], ","))
+}
+
+func (solution) Part1(nums []int) any {
   // find the median, I guess that's the one with lowest cost
   sort.Ints(nums)
 
@@ -20,14 +26,13 @@ func part1(nums []int) {
     cost += int(math.Abs(float64(pos - best)))
   }
 
-  fmt.Println("best:", best, "cost:", cost)
+  return cost
 }
 
-func part2(nums []int) {
+func (solution) Part2(nums []int) any {
   min, max := utils.MinMax(nums)
 
   bestCost := math.Inf(1)
-  best := 0
 
   for _, candidate := range utils.Range(min, max) {
     tot := 0
@@ -38,13 +43,15 @@ func part2(nums []int) {
 
     if float64(tot) < bestCost {
       bestCost = float64(tot)
-      best = candidate
     }
   }
-  fmt.Println("best:", best, "cost:", int(bestCost))
+  return int(bestCost)
 }
 
-func main() {
-  nums := utils.StrsToInts(strings.Split(utils.ReadLines("inp.txt")[0], ","))
-  part2(nums)
+//go:embed examples
+var examples embed.FS
+
+func init() {
+  utils.Register[[]int](2021, 7, solution{})
+  utils.RegisterExamples(2021, 7, examples)
 }
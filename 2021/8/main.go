@@ -1,7 +1,7 @@
-package main
+package day8
 
 import (
-  "fmt"
+  "embed"
   "strings"
   "math"
 
@@ -64,8 +64,13 @@ func parse(lines []string) ([][]string, [][]string) {
   return digits, readouts
 }
 
-func part1() {
-  lines := utils.ReadLines("inp.txt")
+type solution struct{}
+
+func (solution) Parse(input string) []string {
+  return utils.NonEmptyLines(input)
+}
+
+func (solution) Part1(lines []string) any {
   _, readouts := parse(lines)
 
   count := 0
@@ -78,7 +83,7 @@ func part1() {
       }
     }
   }
-  fmt.Println("ez:", count)
+  return count
 }
 
 
@@ -95,14 +100,14 @@ func prop(segOpts LetterSet, allDigits[]string ) (bool, LetterSet) {
   minSize := math.Inf(1)
   ones := 0
   for s, letters := range segOpts{
-    if len(letters) == 0 {
+    if letters.Size() == 0 {
       return false, segOpts
-    } else if len(letters) == 1 {
+    } else if letters.Size() == 1 {
       ones++
       continue
-    } else if float64(len(letters)) < minSize {
+    } else if float64(letters.Size()) < minSize {
       minS = s
-      minSize = float64(len(letters))
+      minSize = float64(letters.Size())
     }
   }
 
@@ -116,11 +121,11 @@ func prop(segOpts LetterSet, allDigits[]string ) (bool, LetterSet) {
     return true, segOpts
   }
 
-  for v := range segOpts[minS] {
+  for _, v := range segOpts[minS].Values() {
     // pretend we just fix this boi to a value
 
     newOpts := copyLetterSet(segOpts)
-    newOpts[minS] = utils.NewStrSet().Add(v)
+    newOpts[minS] = utils.NewStrSet(v)
 
     // remove v from all others
     for ns, letters := range newOpts {
@@ -150,8 +155,8 @@ func readNum(segToL LetterSet, digit string) int {
   }
 
   for n, segs := range numToSegs {
-    ss := utils.NewIntSet().AddAll(segs)
-    if ss.Equals(numSegs) {
+    ss := utils.NewIntSet(segs...)
+    if ss.Equals(&numSegs) {
       return n
     }
   }
@@ -163,7 +168,7 @@ func processLine(digits []string , readouts []string) int {
   letters := strings.Split("abcdefg", "")
   segOptions := LetterSet{}
   for i := 0; i < 7; i++ {
-    segOptions = append(segOptions, utils.NewStrSet().AddAll(letters))
+    segOptions = append(segOptions, utils.NewStrSet(letters...))
   }
 
   observed := LetterSet{}
@@ -191,7 +196,7 @@ func processLine(digits []string , readouts []string) int {
   // limit options for segments based on the observed
   for n, segs := range numToSegs {
     for _, s := range segs {
-      segOptions[s].Intersect(observed[n])
+      segOptions[s].IntersectionUpdate(observed[n].Values())
     }
   }
 
@@ -215,8 +220,7 @@ func processLine(digits []string , readouts []string) int {
 }
 
 
-func part2() {
-  lines := utils.ReadLines("inp.txt")
+func (solution) Part2(lines []string) any {
   // lines := []string{"acedgfb cdfbe gcdfa fbcad dab cefabd cdfgeb eafb cagedb ab | cdfeb fcadb cdfeb cdbaf"}
   digits, readouts := parse(lines)
 
@@ -225,9 +229,13 @@ func part2() {
     tot += processLine(digits[i], readouts[i])
   }
 
-  fmt.Println("Answer:", tot)
+  return tot
 }
 
-func main() {
-  part2()
+//go:embed examples
+var examples embed.FS
+
+func init() {
+  utils.Register[[]string](2021, 8, solution{})
+  utils.RegisterExamples(2021, 8, examples)
 }
@@ -0,0 +1,11 @@
+package y2021
+
+import (
+	"testing"
+
+	"aoc/utils/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.CheckAnswers(t, 2021)
+}
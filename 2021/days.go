@@ -0,0 +1,21 @@
+// Package y2021 registers every Go day of 2021 with the solution registry
+package y2021
+
+import (
+	_ "aoc/2021/1"
+	_ "aoc/2021/10"
+	_ "aoc/2021/11"
+	_ "aoc/2021/12"
+	_ "aoc/2021/13"
+	_ "aoc/2021/14"
+	_ "aoc/2021/15"
+	_ "aoc/2021/16"
+	_ "aoc/2021/2"
+	_ "aoc/2021/3"
+	_ "aoc/2021/4"
+	_ "aoc/2021/5"
+	_ "aoc/2021/6"
+	_ "aoc/2021/7"
+	_ "aoc/2021/8"
+	_ "aoc/2021/9"
+)
@@ -1,10 +1,12 @@
-package main
+package day10
 
 import (
+	"embed"
 	"fmt"
 	"strings"
 
 	"aoc/utils"
+	"aoc/utils/geom"
 )
 
 const (
@@ -15,111 +17,74 @@ const (
 	CORNERS    = "LJ7F"
 )
 
-type Cell struct {
-	row int
-	col int
-}
-
-func (self *Cell) Up() Cell {
-	return Cell{self.row - 1, self.col}
-}
-
-func (self *Cell) Down() Cell {
-	return Cell{self.row + 1, self.col}
-}
-
-func (self *Cell) Left() Cell {
-	return Cell{self.row, self.col - 1}
-}
-
-func (self *Cell) Right() Cell {
-	return Cell{self.row, self.col + 1}
-}
+var (
+	up    = utils.V2{Y: -1}
+	down  = utils.V2{Y: 1}
+	left  = utils.V2{X: -1}
+	right = utils.V2{X: 1}
+)
 
 type Map struct {
-	grid  [][]byte
-	start Cell
+	grid  utils.Grid[byte]
+	start utils.V2
 }
 
-func parseMap(fname string) Map {
-	lines := utils.ReadLines(fname)
-	m := Map{make([][]byte, len(lines)), Cell{0, 0}}
-
-	for i, ln := range lines {
-		m.grid[i] = make([]byte, len(ln))
-		for j, c := range ln {
-			m.grid[i][j] = byte(c)
-
-			if c == 'S' {
-				m.start = Cell{i, j}
-			}
-		}
-	}
-	return m
+func parseMap(input string) Map {
+	grid := utils.ParseByteGrid(utils.NonEmptyLines(input))
+	start, _ := grid.Find(func(c byte) bool { return c == 'S' })
+	return Map{grid, start}
 }
 
-func (self *Map) Print(path []Cell, hl []Cell) {
+func (self *Map) Print(path []utils.V2, hl []utils.V2) {
 	pSet := utils.NewSet(path)
 	hlSet := utils.NewSet(hl)
 
 	var bld strings.Builder
 
-	for i, ln := range self.grid {
-		for j, b := range ln {
-			s := string(b)
-			c := Cell{i, j}
-
-			if hlSet.Contains(c) {
-				s = utils.Blue(s)
-			} else if b == 'S' {
-				s = utils.Green(s)
-			} else if pSet.Contains(c) {
-				s = utils.Red(s)
-			}
-			bld.WriteString(s)
+	for it := self.grid.Iter(); it.Next(); {
+		b := self.grid.At(it.V)
+		s := string(b)
+
+		if hlSet.Contains(it.V) {
+			s = utils.Blue(s)
+		} else if b == 'S' {
+			s = utils.Green(s)
+		} else if pSet.Contains(it.V) {
+			s = utils.Red(s)
+		}
+		bld.WriteString(s)
+		if it.V.X == self.grid.W()-1 {
+			bld.WriteByte('\n')
 		}
-		bld.WriteByte('\n')
 	}
 
 	fmt.Println(bld.String())
 }
 
-func (self *Map) Get(c *Cell) byte {
-	return self.grid[c.row][c.col]
-}
-
 // Get outgoing connections from cell
-func (self *Map) Neighbors(c *Cell) []Cell {
-	var nbors []Cell
-	height := len(self.grid)
-	width := len(self.grid[0])
-	char := rune(self.Get(c))
-
-	north := c.Up()
-	south := c.Down()
-	west := c.Left()
-	east := c.Right()
-
-	if north.row >= 0 && strings.ContainsRune(NORTH_CONN, char) {
-		nbors = append(nbors, north)
-	}
-
-	if south.row < height && strings.ContainsRune(SOUTH_CONN, char) {
-		nbors = append(nbors, south)
-	}
-
-	if west.col >= 0 && strings.ContainsRune(WEST_CONN, char) {
-		nbors = append(nbors, west)
-	}
-
-	if east.col < width && strings.ContainsRune(EAST_CONN, char) {
-		nbors = append(nbors, east)
+func (self *Map) Neighbors(c *utils.V2) []utils.V2 {
+	var nbors []utils.V2
+	char := rune(self.grid.At(*c))
+
+	for _, conn := range []struct {
+		dir   utils.V2
+		chars string
+	}{
+		{up, NORTH_CONN},
+		{down, SOUTH_CONN},
+		{left, WEST_CONN},
+		{right, EAST_CONN},
+	} {
+		nb := c.Add(&conn.dir)
+		if self.grid.InBounds(nb) && strings.ContainsRune(conn.chars, char) {
+			nbors = append(nbors, nb)
+		}
 	}
 	return nbors
 }
 
 // Return whether two cells connect
-func (self *Map) CellsConnect(a *Cell, b *Cell) bool {
+func (self *Map) CellsConnect(a *utils.V2, b *utils.V2) bool {
 	// Check for mutual connection
 	aconnb := false
 	for _, an := range self.Neighbors(a) {
@@ -143,8 +108,8 @@ func (self *Map) CellsConnect(a *Cell, b *Cell) bool {
 }
 
 // Neighbors that mutually connect
-func (self *Map) ConnectedNeighbors(c *Cell) []Cell {
-	var nbors []Cell
+func (self *Map) ConnectedNeighbors(c *utils.V2) []utils.V2 {
+	var nbors []utils.V2
 	for _, nb := range self.Neighbors(c) {
 		if self.CellsConnect(c, &nb) {
 			nbors = append(nbors, nb)
@@ -153,100 +118,55 @@ func (self *Map) ConnectedNeighbors(c *Cell) []Cell {
 	return nbors
 }
 
-func (self *Map) Walk() []Cell {
-	seen := utils.EmptySet[Cell]()
-	path := []Cell{self.start}
-
-	for {
-		lastCell := path[len(path)-1]
-		seen.Add(lastCell)
-
-		nbors := self.ConnectedNeighbors(&lastCell)
-
-		for _, nb := range nbors {
-			if !seen.Contains(nb) {
-				path = append(path, nb)
+// Cells of the loop through the start, with how many steps along the loop
+// each is from the start
+func (self *Map) Loop() map[utils.V2]int {
+	paths := utils.BFS([]utils.V2{self.start}, func(c utils.V2) []utils.V2 {
+		return self.ConnectedNeighbors(&c)
+	}, nil)
+	return paths.Dist
+}
+
+// Cells of the loop in the order they're visited walking it from the start
+func (self *Map) LoopPath() []utils.V2 {
+	path := []utils.V2{self.start}
+	prev, curr := self.start, self.ConnectedNeighbors(&self.start)[0]
+	for curr != self.start {
+		path = append(path, curr)
+		for _, nb := range self.ConnectedNeighbors(&curr) {
+			if nb != prev {
+				prev, curr = curr, nb
 				break
-			} else if len(path) > 2 && nb == self.start {
-				return path
 			}
 		}
 	}
+	return path
 }
 
-func (self *Map) countSAs(path []Cell) byte {
-	start := path[0]
-	startNeighbors := utils.NewSet(self.ConnectedNeighbors(&start))
-
-	if startNeighbors.Contains(start.Up()) {
-		if startNeighbors.Contains(start.Down()) {
-			return '|'
-		} else if startNeighbors.Contains(start.Right()) {
-			return 'L'
-		} else if startNeighbors.Contains(start.Left()) {
-			return 'J'
-		}
-	} else if startNeighbors.Contains(start.Down()) {
-		if startNeighbors.Contains(start.Right()) {
-			return 'F'
-		} else if startNeighbors.Contains(start.Left()) {
-			return '7'
-		}
-	}
-	return '-'
+type solution struct{}
+
+func (solution) Parse(input string) Map {
+	return parseMap(input)
 }
 
-func (self *Map) NumContained(path []Cell) int {
-	sCounts := self.countSAs(path)
-	start := path[0]
-	self.grid[start.row][start.col] = sCounts
-
-	pathCells := utils.NewSet(path)
-	tot := 0
-	insideCells := utils.EmptySet[Cell]()
-	upCorners := "LJ"
-	downCorners := "F7"
-
-	for i := 0; i < len(self.grid); i++ {
-		inside := false
-		seenCorners := 0
-		for j := 0; j < len(self.grid[0]); j++ {
-			b := self.grid[i][j]
-
-			if pathCells.Contains(Cell{i, j}) {
-				// hitting path
-				if b == '|' {
-					inside = !inside
-					seenCorners = 0
-				} else if strings.ContainsRune(upCorners, rune(b)) {
-					seenCorners++
-					if seenCorners == 0 {
-						inside = !inside
-					} else if seenCorners > 1 {
-						seenCorners = 0
-					}
-				} else if strings.ContainsRune(downCorners, rune(b)) {
-					seenCorners--
-					if seenCorners == 0 {
-						inside = !inside
-					} else if seenCorners < -1 {
-						seenCorners = 0
-					}
-				}
-			} else if inside {
-				insideCells.Add(Cell{i, j})
-				tot++
-			}
-		}
+func (solution) Part1(m Map) any {
+	farthest := 0
+	for _, d := range m.Loop() {
+		farthest = utils.Max(farthest, d)
 	}
+	return farthest
+}
 
-	self.Print(path, insideCells.Values())
-	return tot
+func (solution) Part2(m Map) any {
+	// the centers of the loop's cells trace a polygon, and the enclosed cells
+	// are the lattice points strictly inside it
+	return geom.InteriorPoints(m.LoopPath())
 }
 
-func main() {
-	m := parseMap("~/sync/dev/aoc_inputs/2023/10/input.txt")
-	p := m.Walk()
-	fmt.Println("p1:", len(p)/2)
-	fmt.Println("p2:", m.NumContained(p))
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[Map](2023, 10, solution{})
+	utils.RegisterExamples(2023, 10, examples)
 }
@@ -1,6 +1,7 @@
-package main
+package day11
 
 import (
+	"embed"
 	"fmt"
 	"math"
 	"strings"
@@ -99,8 +100,8 @@ func (self *Universe) SumDist() int {
 	return sumDist
 }
 
-func parseUniverse(fname string) Universe {
-	lines := utils.ReadLines(fname)
+func parseUniverse(input string) Universe {
+	lines := utils.NonEmptyLines(input)
 	uni := Universe{make([][]int, len(lines)), []Galaxy{}}
 
 	for i, ln := range lines {
@@ -114,14 +115,26 @@ func parseUniverse(fname string) Universe {
 	return uni
 }
 
-func main() {
-	fname := "~/sync/dev/aoc_inputs/2023/11/input.txt"
-	uni := parseUniverse(fname)
-	// uni.Print()
+type solution struct{}
 
+func (solution) Parse(input string) Universe {
+	return parseUniverse(input)
+}
+
+func (solution) Part1(uni Universe) any {
 	uni.CalculateGalaxies(1)
-	fmt.Println("p1:", uni.SumDist())
+	return uni.SumDist()
+}
 
+func (solution) Part2(uni Universe) any {
 	uni.CalculateGalaxies(1_000_000)
-	fmt.Println("p2:", uni.SumDist())
+	return uni.SumDist()
+}
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[Universe](2023, 11, solution{})
+	utils.RegisterExamples(2023, 11, examples)
 }
@@ -1,7 +1,7 @@
-package main
+package day12
 
 import (
-	"fmt"
+	"embed"
 	"strings"
 
 	"aoc/utils"
@@ -12,8 +12,8 @@ type Puzzle struct {
 	constraints [][]int
 }
 
-func parseInput(fname string) Puzzle {
-	lines := utils.ReadLines(fname)
+func parseInput(input string) Puzzle {
+	lines := utils.NonEmptyLines(input)
 	puzzle := Puzzle{
 		make([][]byte, len(lines)),
 		make([][]int, len(lines)),
@@ -41,47 +41,43 @@ func solve(row []byte, constraints []int) int {
 
 	states := sb.String()
 	endStates := []int{len(states) - 1, len(states) - 2}
-	currStates := make(map[int]int)
-	currStates[0] = 1
+	sc := utils.NewStateCounter(0)
 
 	for _, char := range row {
-		nextStates := make(map[int]int)
-
-		for s, count := range currStates {
+		sc.Step(func(s int, emit func(int)) {
 			switch states[s] {
 			case '.':
 				if char == '#' {
 					if s+1 < len(states) {
-						nextStates[s+1] += count
+						emit(s + 1)
 					}
 				} else {
-					nextStates[s] += count
+					emit(s)
 					if char == '?' && s+1 < len(states) {
-						nextStates[s+1] += count
+						emit(s + 1)
 					}
 				}
 			case '#':
 				if s+1 >= len(states) {
-					continue
+					return
 				}
 
 				if char == '?' {
-					nextStates[s+1] += count
+					emit(s + 1)
 				} else {
 					// non-? char, advance if the next state is that char
 					// (means: if we're #, next is ., only advance if .)
 					if states[s+1] == char {
-						nextStates[s+1] += count
+						emit(s + 1)
 					}
 				}
 			}
-		}
-		currStates = nextStates
+		})
 	}
 
 	tot := 0
 	for _, es := range endStates {
-		tot += currStates[es]
+		tot += sc.Counts[es]
 	}
 	return tot
 }
@@ -111,19 +107,33 @@ func makeP2Inp(r []byte, c []int) ([]byte, []int) {
 	return p2r, p2c
 }
 
-func main() {
-	fname := "~/sync/dev/aoc_inputs/2023/12/input.txt"
-	puzz := parseInput(fname)
+type solution struct{}
+
+func (solution) Parse(input string) Puzzle {
+	return parseInput(input)
+}
+
+func (solution) Part1(puzz Puzzle) any {
+	tot := 0
+	for i, r := range puzz.rows {
+		tot += solve(r, puzz.constraints[i])
+	}
+	return tot
+}
 
-	p1tot := 0
-	p2tot := 0
+func (solution) Part2(puzz Puzzle) any {
+	tot := 0
 	for i, r := range puzz.rows {
-		c := puzz.constraints[i]
-		p1tot += solve(r, c)
-		p2r, p2c := makeP2Inp(r, c)
-		p2tot += solve(p2r, p2c)
+		p2r, p2c := makeP2Inp(r, puzz.constraints[i])
+		tot += solve(p2r, p2c)
 	}
+	return tot
+}
+
+//go:embed examples
+var examples embed.FS
 
-	fmt.Println("p1:", p1tot)
-	fmt.Println("p2:", p2tot)
+func init() {
+	utils.Register[Puzzle](2023, 12, solution{})
+	utils.RegisterExamples(2023, 12, examples)
 }
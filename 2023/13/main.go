@@ -1,13 +1,13 @@
-package main
+package day13
 
 import (
-	"fmt"
+	"embed"
 
 	"aoc/utils"
 )
 
-func parseFile(fname string) [][]string {
-	lines := utils.ReadAllLines(fname)
+func parseFile(input string) [][]string {
+	lines := utils.ParseLines(input)
 
 	var result [][]string
 	result = append(result, []string{})
@@ -40,7 +40,13 @@ func findMirror(m []string) int {
 	return -1
 }
 
-func p1(maps [][]string) {
+type solution struct{}
+
+func (solution) Parse(input string) [][]string {
+	return parseFile(input)
+}
+
+func (solution) Part1(maps [][]string) any {
 	tot := 0
 	for _, m := range maps {
 		row := findMirror(m)
@@ -52,7 +58,7 @@ func p1(maps [][]string) {
 		}
 	}
 
-	fmt.Println("p1:", tot)
+	return tot
 }
 
 type Coord struct {
@@ -89,7 +95,7 @@ func findSingleDiff(m []string) int {
 	return -1
 }
 
-func p2(maps [][]string) {
+func (solution) Part2(maps [][]string) any {
 	tot := 0
 
 	for _, m := range maps {
@@ -102,13 +108,13 @@ func p2(maps [][]string) {
 		}
 	}
 
-	fmt.Println("p2:", tot)
+	return tot
 }
 
-func main() {
-	fname := "~/sync/dev/aoc_inputs/2023/13/input.txt"
-	maps := parseFile(fname)
+//go:embed examples
+var examples embed.FS
 
-	p1(maps)
-	p2(maps)
+func init() {
+	utils.Register[[][]string](2023, 13, solution{})
+	utils.RegisterExamples(2023, 13, examples)
 }
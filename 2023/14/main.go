@@ -1,116 +1,69 @@
-package main
+package day14
 
 import (
-	"fmt"
-	"strings"
+	"embed"
 
 	"aoc/utils"
 )
 
-// Roll rocks to the right to left
-// ..OO#..O  ->  OO..#O..
-func rollLine(ln string) string {
-	newLn := []byte(ln)
+var (
+	NORTH = utils.V2{Y: -1}
+	WEST  = utils.V2{X: -1}
+	SOUTH = utils.V2{Y: 1}
+	EAST  = utils.V2{X: 1}
+)
 
-	toMove := 0
-	for i := 0; i < len(ln); i++ {
-		c := newLn[i]
-		switch c {
-		case '#':
-			toMove = 0
-		case 'O':
-			if toMove > 0 {
-				newLn[i-toMove] = c
-				newLn[i] = '.'
-			}
-		case '.':
-			toMove++
-		}
-	}
-	return string(newLn)
+func isRock(c byte) bool {
+	return c == 'O'
 }
 
-func rollAll(lines []string) []string {
-	newLines := make([]string, len(lines))
-	for i, ln := range lines {
-		newLines[i] = rollLine(ln)
-	}
-	return newLines
+func isEmpty(c byte) bool {
+	return c == '.'
 }
 
-func calcNorthWeight(lines []string) int {
+func calcNorthWeight(gd *utils.Grid[byte]) int {
 	tot := 0
-	for i, ln := range lines {
-		tot += strings.Count(ln, "O") * (len(lines) - i)
+	for _, v := range gd.FindAll(isRock) {
+		tot += gd.H() - v.Y
 	}
 	return tot
 }
 
-func p1(fname string) {
-	// roll rocks north, tally load
-	lines := utils.ReadLines(fname)
+type solution struct{}
 
-	// transpose makes north left, south right, east down, west up
-	lines = rollAll(utils.Transpose(lines))
-
-	// transpose back to get original coordinate system
-	lines = utils.Transpose(lines)
-
-	// calculate
-	fmt.Println("p1:", calcNorthWeight(lines))
+func (solution) Parse(input string) utils.Grid[byte] {
+	return utils.ParseByteGrid(utils.NonEmptyLines(input))
 }
 
-func p2(fname string) {
-	n := 1_000_000_000
-	lines := utils.ReadLines(fname)
-	var states []string
-	uniqS := utils.EmptySet[string]()
-
-	for ci := 0; ci < n; ci++ {
-		// north
-		// transpose makes north left, south right, east down, west up
-		lines = rollAll(utils.Transpose(lines))
-
-		// west
-		// transpose back puts us in original, this will roll west
-		lines = rollAll(utils.Transpose(lines))
-
-		// south
-		// transpose makes north left, south right, east down, west up
-		// reverse makes south left, north right, east down, west up
-		lines = rollAll(utils.ReverseAll(utils.Transpose(lines)))
-
-		// east
-		// this undoes last operation, north up, south down, west left, east right
-		lines = utils.Transpose(utils.ReverseAll(lines))
-		// this rolls east and returns to og
-		lines = utils.ReverseAll(rollAll(utils.ReverseAll(lines)))
-
-		currS := strings.Join(lines, "\n")
-		states = append(states, currS)
-
-		if uniqS.Contains(currS) {
-			cycleLen := 0
-			for i := ci - 1; i >= 0; i-- {
-				if states[i] == states[ci] {
-					cycleLen = ci - i
-					break
-				}
-			}
+func (solution) Part1(gd utils.Grid[byte]) any {
+	// roll rocks north, tally load
+	gd = gd.Clone()
+	gd.Slide(NORTH, isRock, isEmpty)
+	return calcNorthWeight(&gd)
+}
 
-			remain := n - ci - 1
-			// reset state to what it will be when we finish
-			lines = strings.Split(states[ci-cycleLen+remain%cycleLen], "\n")
-			break
+func (solution) Part2(gd utils.Grid[byte]) any {
+	spin := func(gd utils.Grid[byte]) utils.Grid[byte] {
+		gd = gd.Clone()
+		for _, dir := range []utils.V2{NORTH, WEST, SOUTH, EAST} {
+			gd.Slide(dir, isRock, isEmpty)
 		}
-		uniqS.Add(currS)
+		return gd
+	}
+	key := func(gd utils.Grid[byte]) string {
+		return gd.String()
 	}
 
-	fmt.Println("p2:", calcNorthWeight(lines))
+	// the platform settles into a loop long before a billion spins
+	cycle := utils.FindCycle(gd, spin, key)
+	gd = cycle.At(1_000_000_000)
+	return calcNorthWeight(&gd)
 }
 
-func main() {
-	fname := "~/sync/dev/aoc_inputs/2023/14/input.txt"
-	p1(fname)
-	p2(fname)
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[utils.Grid[byte]](2023, 14, solution{})
+	utils.RegisterExamples(2023, 14, examples)
 }
@@ -1,7 +1,10 @@
-package main
+package day14
 
 import (
 	"testing"
+
+	"aoc/utils"
+	"aoc/utils/aoctest"
 )
 
 func TestRoll(t *testing.T) {
@@ -20,11 +23,16 @@ func TestRoll(t *testing.T) {
 		"OO......#.",
 	}
 
+	gd := utils.ParseByteGrid(in)
+	gd.Slide(WEST, isRock, isEmpty)
 	for i := range in {
-		ans := rollLine(in[i])
+		ans := string(gd.Row(i))
 		if out[i] != ans {
 			t.Fatalf("Roll %s expected %s got %s", in[i], out[i], ans)
 		}
 	}
+}
 
+func TestExamples(t *testing.T) {
+	aoctest.TestExamples(t, 2023, 14)
 }
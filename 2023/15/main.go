@@ -1,7 +1,7 @@
-package main
+package day15
 
 import (
-	"fmt"
+	"embed"
 	"regexp"
 	"strings"
 
@@ -63,16 +63,22 @@ func (self *BadMap) Remove(lens Lens) {
 	}
 }
 
-func p1(instructions []string) {
+type solution struct{}
+
+func (solution) Parse(input string) []string {
+	return strings.Split(utils.NonEmptyLines(input)[0], ",")
+}
+
+func (solution) Part1(instructions []string) any {
 	tot := 0
 
 	for _, inst := range instructions {
 		tot += hash(inst)
 	}
-	fmt.Println("p1:", tot)
+	return tot
 }
 
-func p2(instructions []string) {
+func (solution) Part2(instructions []string) any {
 	re := regexp.MustCompile("([a-z]+)([-=])(\\d+)?")
 	bm := NewBadMap()
 
@@ -103,12 +109,13 @@ func p2(instructions []string) {
 		}
 	}
 
-	fmt.Println("p2:", tot)
+	return tot
 }
 
-func main() {
-	fname := "~/sync/dev/aoc_inputs/2023/15/input.txt"
-	instructions := strings.Split(utils.ReadLines(fname)[0], ",")
-	p1(instructions)
-	p2(instructions)
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[[]string](2023, 15, solution{})
+	utils.RegisterExamples(2023, 15, examples)
 }
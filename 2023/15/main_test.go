@@ -1,7 +1,9 @@
-package main
+package day15
 
 import (
 	"testing"
+
+	"aoc/utils/aoctest"
 )
 
 func TestHash(t *testing.T) {
@@ -12,3 +14,7 @@ func TestHash(t *testing.T) {
 		t.Fatalf("Expected hash('HASH') = 52, got %d", v)
 	}
 }
+
+func TestExamples(t *testing.T) {
+	aoctest.TestExamples(t, 2023, 15)
+}
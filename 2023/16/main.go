@@ -1,7 +1,7 @@
-package main
+package day16
 
 import (
-	"fmt"
+	"embed"
 
 	"aoc/utils"
 )
@@ -11,58 +11,15 @@ type Beam struct {
 	dir utils.V2
 }
 
-func parseMap(fname string) [][]byte {
-	lines := utils.ReadLines(fname)
-
-	mp := make([][]byte, len(lines))
-	for i, ln := range lines {
-		mp[i] = []byte(ln)
-	}
-	return mp
-}
-
-func inBounds(v *utils.V2, mp [][]byte) bool {
-	return v.Y < len(mp) && v.Y >= 0 && v.X < len(mp[0]) && v.X >= 0
-}
-
-func printWithCoord(mp [][]byte, c utils.V2) {
-	for y, ln := range mp {
-		if c.Y == y {
-			rc := utils.Red(string(ln[c.X]))
-			if c.X+1 >= len(ln) {
-				fmt.Printf("%s%s\n", string(ln[:c.X]), rc)
-			} else {
-				fmt.Printf("%s%s%s\n", string(ln[:c.X]), rc, string(ln[c.X+1:]))
-			}
-		} else {
-			fmt.Println(string(ln))
-		}
-	}
-}
-
-func walk(mp [][]byte, start Beam) int {
+func walk(mp *utils.Grid[byte], start Beam) int {
 	RIGHT := utils.V2{X: 1, Y: 0}
 	LEFT := utils.V2{X: -1, Y: 0}
 	UP := utils.V2{X: 0, Y: -1}
 	DOWN := utils.V2{X: 0, Y: 1}
 
-	energized := make(map[utils.V2]int)
-	seen := utils.EmptySet[Beam]()
-	beams := []Beam{start}
-
-	for len(beams) > 0 {
-		bm := beams[0]
-		beams = beams[1:]
-
-		if !inBounds(&bm.pos, mp) {
-			continue
-		}
-		if seen.Contains(bm) {
-			continue
-		}
-		seen.Add(bm)
-		energized[bm.pos] += 1
-		nextC := mp[bm.pos.Y][bm.pos.X]
+	// beams leaving the cell of bm
+	step := func(bm Beam) []Beam {
+		nextC := mp.At(bm.pos)
 
 		var next []Beam
 
@@ -114,59 +71,73 @@ func walk(mp [][]byte, start Beam) int {
 			}
 		}
 
+		var inside []Beam
 		for _, newBm := range next {
-			if !inBounds(&newBm.pos, mp) {
-				continue
+			if mp.InBounds(newBm.pos) {
+				inside = append(inside, newBm)
 			}
-			beams = append(beams, newBm)
 		}
+		return inside
 	}
-	return len(energized)
+
+	energized := utils.EmptySet[utils.V2]()
+	for bm := range utils.BFS([]Beam{start}, step, nil).Dist {
+		energized.Add(bm.pos)
+	}
+	return energized.Size()
 }
 
-func p1(mp [][]byte) {
-	fmt.Println("p1:", walk(mp, Beam{
+type solution struct{}
+
+func (solution) Parse(input string) utils.Grid[byte] {
+	return utils.ParseByteGrid(utils.NonEmptyLines(input))
+}
+
+func (solution) Part1(mp utils.Grid[byte]) any {
+	return walk(&mp, Beam{
 		utils.V2{X: 0, Y: 0},
 		utils.V2{X: 1, Y: 0},
-	}))
+	})
 }
 
-func p2(mp [][]byte) {
+func (solution) Part2(mp utils.Grid[byte]) any {
 	maxE := 0
 	// rows
-	for i := range mp {
-		eL := walk(mp, Beam{
+	for i := 0; i < mp.H(); i++ {
+		eL := walk(&mp, Beam{
 			utils.V2{X: 0, Y: i}, // start coord
 			utils.V2{X: 1, Y: 0}, // dir
 		})
 		maxE = utils.Max(eL, maxE)
 
-		eR := walk(mp, Beam{
-			utils.V2{X: len(mp[0]) - 1, Y: i}, // start coord
-			utils.V2{X: -1, Y: 0},             // dir
+		eR := walk(&mp, Beam{
+			utils.V2{X: mp.W() - 1, Y: i}, // start coord
+			utils.V2{X: -1, Y: 0},         // dir
 		})
 		maxE = utils.Max(eR, maxE)
 	}
 
 	// cols
-	for i := range mp[0] {
-		eT := walk(mp, Beam{
+	for i := 0; i < mp.W(); i++ {
+		eT := walk(&mp, Beam{
 			utils.V2{X: i, Y: 0}, // start coord
 			utils.V2{X: 0, Y: 1}, // dir
 		})
 		maxE = utils.Max(eT, maxE)
 
-		eB := walk(mp, Beam{
-			utils.V2{X: i, Y: len(mp) - 1}, // start coord
-			utils.V2{X: 0, Y: -1},          // dir
+		eB := walk(&mp, Beam{
+			utils.V2{X: i, Y: mp.H() - 1}, // start coord
+			utils.V2{X: 0, Y: -1},         // dir
 		})
 		maxE = utils.Max(eB, maxE)
 	}
-	fmt.Println("p2:", maxE)
+	return maxE
 }
 
-func main() {
-	mp := parseMap("~/sync/dev/aoc_inputs/2023/16/input.txt")
-	p1(mp)
-	p2(mp)
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[utils.Grid[byte]](2023, 16, solution{})
+	utils.RegisterExamples(2023, 16, examples)
 }
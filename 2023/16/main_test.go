@@ -0,0 +1,11 @@
+package day16
+
+import (
+	"testing"
+
+	"aoc/utils/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	aoctest.TestExamples(t, 2023, 16)
+}
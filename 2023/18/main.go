@@ -1,22 +1,17 @@
-package main
+package day18
 
 import (
-	"fmt"
+	"embed"
 	"regexp"
 	"strconv"
 
 	"aoc/utils"
+	"aoc/utils/geom"
 )
 
-type Dig struct {
-	dir   utils.V2
-	count int
-}
-
-func parseP1(fname string) []Dig {
+func parseP1(lines []string) []geom.Step {
 	re := regexp.MustCompile("([A-Z]) ([0-9]+) ")
-	lines := utils.ReadLines(fname)
-	digs := make([]Dig, len(lines))
+	digs := make([]geom.Step, len(lines))
 
 	for i, ln := range lines {
 		m := re.FindStringSubmatch(ln)
@@ -32,15 +27,14 @@ func parseP1(fname string) []Dig {
 			dir = utils.V2{X: 0, Y: 1}
 
 		}
-		digs[i] = Dig{dir, utils.StrToInt(m[2])}
+		digs[i] = geom.Step{Dir: dir, Len: utils.StrToInt(m[2])}
 	}
 	return digs
 }
 
-func parseP2(fname string) []Dig {
+func parseP2(lines []string) []geom.Step {
 	re := regexp.MustCompile("\\(#([a-z0-9]+)\\)")
-	lines := utils.ReadLines(fname)
-	digs := make([]Dig, len(lines))
+	digs := make([]geom.Step, len(lines))
 
 	for i, ln := range lines {
 		m := re.FindStringSubmatch(ln)
@@ -57,50 +51,35 @@ func parseP2(fname string) []Dig {
 
 		}
 		v, _ := strconv.ParseInt(m[1][:5], 16, 32)
-		digs[i] = Dig{dir, int(v)}
+		digs[i] = geom.Step{Dir: dir, Len: int(v)}
 	}
 	return digs
 }
 
-func corners(plan []Dig) ([]utils.V2, int) {
-	length := 0
-	pos := utils.V2{}
-	allPos := []utils.V2{}
+// Cubic meters dug out: the trench and everything inside it
+func solve(plan []geom.Step) int {
+	return geom.EnclosedPoints(geom.FromSteps(utils.V2{}, plan))
+}
 
-	for _, d := range plan {
-		length += d.count
-		vec := d.dir.Mul(d.count)
-		pos = pos.Add(&vec)
-		allPos = append(allPos, pos)
-	}
-	return allPos, length
+type solution struct{}
+
+// The plan is read differently in each part, so parsing happens per part
+func (solution) Parse(input string) []string {
+	return utils.NonEmptyLines(input)
 }
 
-func shoelace(points []utils.V2) float64 {
-	// https://en.wikipedia.org/wiki/Shoelace_formula
-	area := 0
-	for i, v := range points {
-		if i == len(points)-1 {
-			i = 0
-		} else {
-			i += 1
-		}
-		area += (v.Y + points[i].Y) * (v.X - points[i].X)
-	}
-	return float64(area) / 2
+func (solution) Part1(lines []string) any {
+	return solve(parseP1(lines))
 }
 
-func solve(plan []Dig) int {
-	points, length := corners(plan)
-	area := shoelace(points)
-	// picks: https://en.wikipedia.org/wiki/Pick%27s_theorem
-	interior := area - float64(length)/2 + 1
-	return length + int(interior)
+func (solution) Part2(lines []string) any {
+	return solve(parseP2(lines))
 }
 
-func main() {
-	fname := "~/sync/dev/aoc_inputs/2023/18/input.txt"
+//go:embed examples
+var examples embed.FS
 
-	fmt.Println("p1:", solve(parseP1(fname)))
-	fmt.Println("p2:", solve(parseP2(fname)))
+func init() {
+	utils.Register[[]string](2023, 18, solution{})
+	utils.RegisterExamples(2023, 18, examples)
 }
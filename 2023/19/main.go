@@ -1,7 +1,7 @@
-package main
+package day19
 
 import (
-	"fmt"
+	"embed"
 	"regexp"
 	"strings"
 
@@ -10,6 +10,9 @@ import (
 
 const MAX_VAL = 4000
 
+// Part attributes in the order of the axes of a box of ratings
+const ATTRS = "xmas"
+
 type Part map[string]int
 
 func (self *Part) Total() int {
@@ -27,43 +30,16 @@ type Rule struct {
 	next string
 }
 
-// Return the range of values that satisfy this rule
-func (self *Rule) GetRange() utils.V2 {
+// Split the box of possible ratings into the part that satisfies this rule
+// and the rest
+func (self *Rule) Split(box utils.Box[int]) (utils.Box[int], utils.Box[int]) {
+	axis := strings.Index(ATTRS, self.attr)
 	switch self.cmp {
 	case "<":
-		return utils.V2{X: 1, Y: self.val}
+		return box.SplitAt(axis, self.val)
 	case ">":
-		return utils.V2{X: self.val + 1, Y: MAX_VAL + 1}
-	default:
-		panic("Bad")
-	}
-}
-
-// Update a range based on this rule's constraint
-func (self *Rule) UpdateRange(rn utils.V2) utils.V2 {
-	rrng := self.GetRange()
-	return utils.V2{
-		X: utils.Max(rrng.X, rn.X),
-		Y: utils.Min(rrng.Y, rn.Y),
-	}
-}
-
-func (self *Rule) Invert() Rule {
-	switch self.cmp {
-	case "<":
-		return Rule{
-			self.attr,
-			">",
-			self.val - 1,
-			"",
-		}
-	case ">":
-		return Rule{
-			self.attr,
-			"<",
-			self.val + 1,
-			"",
-		}
+		fail, pass := box.SplitAt(axis, self.val+1)
+		return pass, fail
 	default:
 		panic("Bad")
 	}
@@ -96,8 +72,13 @@ func (self *Workflow) Eval(p Part) string {
 	return "ERR"
 }
 
-func parse(fname string) (map[string]Workflow, []Part) {
-	lines := utils.ReadAllLines(fname)
+type System struct {
+	flows map[string]Workflow
+	parts []Part
+}
+
+func parse(input string) System {
+	lines := utils.ParseLines(input)
 	reWorkflowLine := regexp.MustCompile("([a-z]+)\\{(.*)\\}")
 	reWorkflow := regexp.MustCompile("([a-z])([><])([0-9]+):([a-zA-z]+)")
 	rePart := regexp.MustCompile("([a-z])=([0-9]+)")
@@ -150,15 +131,20 @@ func parse(fname string) (map[string]Workflow, []Part) {
 			parts = append(parts, p)
 		}
 	}
-	return flows, parts
+	return System{flows, parts}
+}
+
+type solution struct{}
+
+func (solution) Parse(input string) System {
+	return parse(input)
 }
 
-func p1(fname string) int {
-	flows, parts := parse(fname)
+func (solution) Part1(sys System) any {
 	tot := 0
 
-	for _, p := range parts {
-		currFlow := flows["in"]
+	for _, p := range sys.parts {
+		currFlow := sys.flows["in"]
 		done := false
 		for !done {
 			next := currFlow.Eval(p)
@@ -171,7 +157,7 @@ func p1(fname string) int {
 			case "ERR":
 				panic("Fuck")
 			default:
-				currFlow = flows[next]
+				currFlow = sys.flows[next]
 			}
 		}
 	}
@@ -179,67 +165,52 @@ func p1(fname string) int {
 }
 
 type State struct {
-	curr        string
-	constraints map[string]utils.V2
+	curr string
+	box  utils.Box[int]
 }
 
-func p2(fname string) int {
-	flows, _ := parse(fname)
-
-	tot := 0
-	paths := []State{{"in", make(map[string]utils.V2)}}
-	paths[0].constraints["x"] = utils.V2{X: 1, Y: MAX_VAL + 1}
-	paths[0].constraints["m"] = utils.V2{X: 1, Y: MAX_VAL + 1}
-	paths[0].constraints["a"] = utils.V2{X: 1, Y: MAX_VAL + 1}
-	paths[0].constraints["s"] = utils.V2{X: 1, Y: MAX_VAL + 1}
+func (solution) Part2(sys System) any {
+	rating := utils.Interval[int]{Lo: 1, Hi: MAX_VAL + 1}
+	paths := []State{{"in", utils.NewBox(rating, rating, rating, rating)}}
+	var accepted []utils.Box[int]
 
 	for len(paths) > 0 {
 		p := paths[0]
 		paths = paths[1:]
 
 		if p.curr == "A" {
-			// accepted path, tally combinations and add
-			comb := 1
-			for _, rng := range p.constraints {
-				comb *= rng.Y - rng.X
-			}
-			tot += comb
+			accepted = append(accepted, p.box)
 			continue
-		} else if p.curr == "R" {
+		} else if p.curr == "R" || p.box.Empty() {
 			// rejected path, drop
 			continue
 		}
 
 		// expand path
-		f := flows[p.curr]
+		f := sys.flows[p.curr]
 
 		for _, r := range f.rules {
 			if r.cmp != "" {
-				// add a new path for taking this branch
-				nc := utils.CopyMap(p.constraints)
-				nc[r.attr] = r.UpdateRange(nc[r.attr])
-				paths = append(paths, State{
-					r.next,
-					nc,
-				})
-
-				// add the opposite rule to constrain not taking this branch
-				tmpr := r.Invert()
-				p.constraints[r.attr] = tmpr.UpdateRange(p.constraints[r.attr])
+				// add a new path for taking this branch, and constrain the rest
+				// of the rules to not taking it
+				var pass utils.Box[int]
+				pass, p.box = r.Split(p.box)
+				paths = append(paths, State{r.next, pass})
 			} else {
 				// reached a rule with no comparison, advance to next state
-				paths = append(paths, State{
-					r.next,
-					p.constraints,
-				})
+				paths = append(paths, State{r.next, p.box})
 			}
 		}
 	}
-	return tot
+
+	// every path splits off its own part of the ratings so none overlap
+	return utils.Volume(accepted)
 }
 
-func main() {
-	fname := "~/sync/dev/aoc_inputs/2023/19/input.txt"
-	fmt.Println("p1:", p1(fname))
-	fmt.Println("p2:", p2(fname))
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[System](2023, 19, solution{})
+	utils.RegisterExamples(2023, 19, examples)
 }
@@ -1,7 +1,7 @@
-package main
+package day2
 
 import (
-	"fmt"
+	"embed"
 	"strconv"
 	"strings"
 
@@ -68,14 +68,21 @@ func parseGame(ln string) game {
 	return gm
 }
 
-func p1() {
-	lines := utils.ReadLines("input.txt")
+type solution struct{}
+
+func (solution) Parse(input string) []game {
+	var games []game
+	for _, ln := range utils.NonEmptyLines(input) {
+		games = append(games, parseGame(ln))
+	}
+	return games
+}
+
+func (solution) Part1(games []game) any {
 	constraints := rgb{12, 13, 14}
 
 	total := 0
-	for _, ln := range lines {
-		gm := parseGame(ln)
-
+	for _, gm := range games {
 		maxes := gm.Maxes()
 		if maxes.red > constraints.red ||
 			maxes.green > constraints.green ||
@@ -87,23 +94,23 @@ func p1() {
 		total += gm.id
 	}
 
-	fmt.Println("Total:", total)
+	return total
 }
 
-func p2() {
-	lines := utils.ReadLines("input.txt")
-
+func (solution) Part2(games []game) any {
 	total := 0
-	for _, ln := range lines {
-		gm := parseGame(ln)
-
+	for _, gm := range games {
 		maxes := gm.Maxes()
 		total += maxes.red * maxes.green * maxes.blue
 	}
 
-	fmt.Println("Total:", total)
+	return total
 }
 
-func main() {
-	p2()
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[[]game](2023, 2, solution{})
+	utils.RegisterExamples(2023, 2, examples)
 }
@@ -1,262 +1,110 @@
-package main
+package day20
 
 import (
+	"embed"
 	"fmt"
-	"regexp"
-	"strings"
+	"sort"
 
 	"aoc/utils"
+	"aoc/utils/numth"
+	"aoc/utils/pulse"
 )
 
-const (
-	NO_PULSE = -1
-	LOW      = 0
-	HIGH     = 1
-)
-
-type Node struct {
-	name      string
-	typ       string
-	outputs   []string
-	flipState int
-	conjState map[string]int
-}
-
-func (self *Node) isStartingState() bool {
-	switch self.typ {
-	case "%":
-		return self.flipState == LOW
-	case "&":
-		s := 0
-		for _, v := range self.conjState {
-			s += v
-		}
-		return s == 0
-	default:
-		return true
-	}
-}
+const MAX_PRESSES = 100_000
 
-func (self *Node) Reset() {
-	self.flipState = LOW
-	for k := range self.conjState {
-		self.conjState[k] = LOW
-	}
-}
-
-func (self *Node) Receive(p Pulse) int {
-	switch self.typ {
-	case "%":
-		if p.val == HIGH {
-			return NO_PULSE
-		}
-		if self.flipState == HIGH {
-			self.flipState = LOW
-		} else {
-			self.flipState = HIGH
-		}
-		return self.flipState
-
-	case "&":
-		self.conjState[p.src] = p.val
-		allHigh := true
-		for _, pv := range self.conjState {
-			allHigh = allHigh && pv == HIGH
-		}
-		if allHigh {
-			return LOW
-		}
-		return HIGH
-	case "broadcaster":
-		return p.val
-	default:
-		panic("Bad")
-	}
-}
+type solution struct{}
 
-type Pulse struct {
-	src  string
-	dest string
-	val  int
+func (solution) Parse(input string) *pulse.Circuit {
+	return pulse.Parse(utils.NonEmptyLines(input))
 }
 
-func parse(fname string) ([]Node, map[string]int) {
-	lines := utils.ReadLines(fname)
-	lnRe := regexp.MustCompile("([%&]*)([a-z]+) -> (.*)")
-
-	var nodes []Node
-	nameMap := make(map[string]int)
-
-	for _, ln := range lines {
-		m := lnRe.FindStringSubmatch(ln)
-		typ := m[1]
-		if m[2] == "broadcaster" {
-			typ = "broadcaster"
-		}
-
-		nodes = append(nodes, Node{
-			name:      m[2],
-			typ:       typ,
-			outputs:   strings.Split(m[3], ", "),
-			flipState: 0,
-		})
-		nameMap[m[2]] = len(nodes) - 1
-	}
-
-	// populate conj bois
-	for i, n := range nodes {
-		if n.typ != "&" {
-			continue
-		}
-
-		nodes[i].conjState = make(map[string]int)
-
-		for _, other := range nodes {
-			if other.name == n.name {
-				continue
-			}
+func (solution) Part1(c *pulse.Circuit) any {
+	c.Reset()
+	start := c.State()
 
-			for _, o := range other.outputs {
-				if o == n.name {
-					nodes[i].conjState[other.name] = 0
-					break
-				}
-			}
-		}
-	}
-
-	return nodes, nameMap
-}
-
-type Result struct {
-	low     int
-	high    int
-	gotHigh bool
-}
-
-func run(nodes []Node, nameMap map[string]int, lookFor string) Result {
-	res := Result{low: 1, high: 0}
-	queue := []Pulse{{"button", "broadcaster", LOW}}
-
-	for len(queue) > 0 {
-		p := queue[0]
-		queue = queue[1:]
-
-		i, ok := nameMap[p.dest]
-		if !ok {
-			continue
-		}
-
-		n := &nodes[i]
-		rv := n.Receive(p)
-		if n.name == lookFor && rv == HIGH {
-			res.gotHigh = true
-			return res
-		}
-
-		if rv == NO_PULSE {
-			continue
-		}
-
-		for _, next := range n.outputs {
-			if rv == HIGH {
-				res.high++
-			} else {
-				res.low++
-			}
-			queue = append(queue, Pulse{
-				src:  n.name,
-				dest: next,
-				val:  rv,
-			})
-		}
-	}
-	return res
-}
-
-func p1(fname string) {
-	nodes, nameMap := parse(fname)
-
-	cycleTot := Result{}
-	var cycle []Result
+	cycleTot := pulse.Stats{}
+	var cycle []pulse.Stats
 
 	for i := 0; i < 1000; i++ {
-		r := run(nodes, nameMap, "")
+		r := c.Press()
 
-		cycleTot.low += r.low
-		cycleTot.high += r.high
+		cycleTot.Low += r.Low
+		cycleTot.High += r.High
 		cycle = append(cycle, r)
 
-		allOg := true
-		for i := range nodes {
-			allOg = allOg && nodes[i].isStartingState()
-		}
-
-		if allOg {
+		if c.State() == start {
 			break
 		}
 	}
 
 	rem := 1000 % len(cycle)
-	low := cycleTot.low * (1000 / len(cycle))
-	high := cycleTot.high * (1000 / len(cycle))
+	low := cycleTot.Low * (1000 / len(cycle))
+	high := cycleTot.High * (1000 / len(cycle))
 
 	for i := 0; i < rem; i++ {
-		low += cycle[i].low
-		high += cycle[i].high
+		low += cycle[i].Low
+		high += cycle[i].High
 	}
 
-	fmt.Println("p1:", len(cycle), low*high)
+	return low * high
 }
 
-type Req struct {
-	name   string
-	output int
-}
+func (solution) Part2(c *pulse.Circuit) any {
+	c.Reset()
 
-func findRx(nodes []Node) int {
-	for i := range nodes {
-		n := &nodes[i]
-		for _, o := range n.outputs {
-			if o == "rx" {
-				return i
-			}
-		}
+	feeders := c.Inputs("rx")
+	if len(feeders) != 1 {
+		panic(fmt.Sprintf("expected one module to send to rx, got %v", feeders))
+	}
+	m, _ := c.Module(feeders[0])
+	feeder, ok := m.(*pulse.Conjunction)
+	if !ok {
+		panic(fmt.Sprintf("rx is fed by %s, which isn't a conjunction", feeders[0]))
 	}
-	return -1
-}
 
-func p2(fname string) {
-	nodes, nameMap := parse(fname)
-	lookFor := make(map[string]int)
-	lookFor["kk"] = 0
-	lookFor["gl"] = 0
-	lookFor["bb"] = 0
-	lookFor["mr"] = 0
+	// rx gets a low pulse once every input of its feeder has last sent it a
+	// high, so watch when each of them does
+	inputs := append([]string(nil), feeder.Inputs()...)
+	sort.Strings(inputs)
+	hits := make(map[string][]int)
 
-	for l := range lookFor {
-		for i := range nodes {
-			nodes[i].Reset()
+	measured := func() bool {
+		for _, in := range inputs {
+			if len(hits[in]) < 3 {
+				return false
+			}
 		}
-
-		for i := 0; i < 10000; i++ {
-			r := run(nodes, nameMap, l)
-			if r.gotHigh {
-				lookFor[l] = i + 1
-				break
+		return true
+	}
+	for press := 1; !measured(); press++ {
+		if press > MAX_PRESSES {
+			panic(fmt.Sprintf("inputs of %s didn't all repeat within %d presses", feeders[0], MAX_PRESSES))
+		}
+		sent := c.Press().Sent
+		for _, in := range inputs {
+			if sent[in][pulse.High] > 0 {
+				hits[in] = append(hits[in], press)
 			}
 		}
 	}
 
-	presses := 1
-	for _, v := range lookFor {
-		presses *= v
+	// the highs have to repeat at multiples of the first one for the presses
+	// where they all line up to be the multiples of every cycle
+	var cycles []int
+	for _, in := range inputs {
+		h := hits[in]
+		if h[1] != 2*h[0] || h[2] != 3*h[0] {
+			panic(fmt.Sprintf("%s isn't periodic, high at presses %v", in, h[:3]))
+		}
+		cycles = append(cycles, h[0])
 	}
-	fmt.Println("p2:", presses)
+	return numth.LCM(cycles...)
 }
 
-func main() {
-	fname := "~/sync/dev/aoc_inputs/2023/20/input.txt"
-	p1(fname)
-	p2(fname)
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[*pulse.Circuit](2023, 20, solution{})
+	utils.RegisterExamples(2023, 20, examples)
 }
@@ -1,7 +1,7 @@
-package main
+package day3
 
 import (
-	"fmt"
+	"embed"
 	"math"
 	"regexp"
 
@@ -27,8 +27,8 @@ type Schematic struct {
 	symbols []Point
 }
 
-func parseSchematic(fname string) Schematic {
-	lines := utils.ReadLines(fname)
+func parseSchematic(input string) Schematic {
+	lines := utils.NonEmptyLines(input)
 	var schem Schematic
 
 	numRegex := regexp.MustCompile("[0-9]+")
@@ -60,7 +60,13 @@ func parseSchematic(fname string) Schematic {
 	return schem
 }
 
-func p1(schem *Schematic) int {
+type solution struct{}
+
+func (solution) Parse(input string) Schematic {
+	return parseSchematic(input)
+}
+
+func (solution) Part1(schem Schematic) any {
 	sumP1 := 0
 	for _, n := range schem.nums {
 		for _, sym := range append(schem.gears, schem.symbols...) {
@@ -75,7 +81,7 @@ func p1(schem *Schematic) int {
 	return sumP1
 }
 
-func p2(schem *Schematic) int {
+func (solution) Part2(schem Schematic) any {
 	sumP2 := 0
 	for _, sym := range schem.gears {
 		var near []int
@@ -94,8 +100,10 @@ func p2(schem *Schematic) int {
 	return sumP2
 }
 
-func main() {
-	schem := parseSchematic("input.txt")
-	fmt.Println("p1:", p1(&schem))
-	fmt.Println("p2:", p2(&schem))
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[Schematic](2023, 3, solution{})
+	utils.RegisterExamples(2023, 3, examples)
 }
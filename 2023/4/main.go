@@ -1,7 +1,7 @@
-package main
+package day4
 
 import (
-	"fmt"
+	"embed"
 	"math"
 	"regexp"
 	"strings"
@@ -31,20 +31,22 @@ func numWinners(ln string) int {
 	return mine.Size()
 }
 
-func p1(fname string) {
-	lines := utils.ReadLines(fname)
+type solution struct{}
 
+func (solution) Parse(input string) []string {
+	return utils.NonEmptyLines(input)
+}
+
+func (solution) Part1(cards []string) any {
 	score := 0
-	for _, ln := range lines {
+	for _, ln := range cards {
 		score += int(math.Pow(2, float64(numWinners(ln))-1))
 	}
 
-	fmt.Println("p1:", score)
+	return score
 }
 
-func p2(fname string) {
-	cards := utils.ReadLines(fname)
-
+func (solution) Part2(cards []string) any {
 	counts := make([]int, len(cards))
 	for i, c := range cards {
 		counts[i] += 1
@@ -54,10 +56,13 @@ func p2(fname string) {
 		}
 	}
 
-	fmt.Println("p2:", utils.Sum(counts))
+	return utils.Sum(counts)
 }
 
-func main() {
-	p1("input.txt")
-	p2("input.txt")
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[[]string](2023, 4, solution{})
+	utils.RegisterExamples(2023, 4, examples)
 }
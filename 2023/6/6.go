@@ -1,7 +1,7 @@
-package main
+package day6
 
 import (
-	"fmt"
+	"embed"
 	"math"
 	"regexp"
 
@@ -15,8 +15,13 @@ func waysToWin(time, bestDist int) int {
 	return int(math.Floor(root2)-math.Ceil(root1)) + 1
 }
 
-func p1(fname string) {
-	lines := utils.ReadLines(fname)
+type solution struct{}
+
+func (solution) Parse(input string) []string {
+	return utils.NonEmptyLines(input)
+}
+
+func (solution) Part1(lines []string) any {
 	re := regexp.MustCompile("[0-9]+")
 	times := utils.StrsToInts(re.FindAllString(lines[0], -1))
 	dists := utils.StrsToInts(re.FindAllString(lines[1], -1))
@@ -25,19 +30,20 @@ func p1(fname string) {
 	for i := 0; i < len(times); i++ {
 		wins *= waysToWin(times[i], dists[i])
 	}
-	fmt.Println("p1:", wins)
+	return wins
 }
 
-func p2(fname string) {
-	lines := utils.ReadLines(fname)
+func (solution) Part2(lines []string) any {
 	re := regexp.MustCompile("[^0-9]+")
 	time := utils.StrToInt(re.ReplaceAllString(lines[0], ""))
 	dist := utils.StrToInt(re.ReplaceAllString(lines[1], ""))
-	fmt.Println("p2:", waysToWin(time, dist))
+	return waysToWin(time, dist)
 }
 
-func main() {
-	fname := "~/sync/dev/aoc_inputs/2023/6/input.txt"
-	p1(fname)
-	p2(fname)
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[[]string](2023, 6, solution{})
+	utils.RegisterExamples(2023, 6, examples)
 }
@@ -1,7 +1,7 @@
-package main
+package day7
 
 import (
-	"fmt"
+	"embed"
 	"sort"
 	"strings"
 
@@ -60,8 +60,8 @@ func (self *hand) getType(jokerWild bool) int {
 	return HC
 }
 
-func parseHands(fname string) []hand {
-	lines := utils.ReadLines(fname)
+func parseHands(input string) []hand {
+	lines := utils.NonEmptyLines(input)
 	var hands []hand
 	for i, ln := range lines {
 		parts := strings.Split(ln, " ")
@@ -117,8 +117,7 @@ func compareHands(a *hand, b *hand, jokerWild bool) int {
 	return 0
 }
 
-func doPart(fname string, jokerWild bool) int {
-	hands := parseHands(fname)
+func doPart(hands []hand, jokerWild bool) int {
 	// sort backwards for rank
 	sort.Slice(hands, func(i, j int) bool {
 		return compareHands(&hands[i], &hands[j], jokerWild) >= 0
@@ -130,8 +129,24 @@ func doPart(fname string, jokerWild bool) int {
 	return winnings
 }
 
-func main() {
-	fname := "~/sync/dev/aoc_inputs/2023/7/input.txt"
-	fmt.Println("p1:", doPart(fname, false))
-	fmt.Println("p2:", doPart(fname, true))
+type solution struct{}
+
+func (solution) Parse(input string) []hand {
+	return parseHands(input)
+}
+
+func (solution) Part1(hands []hand) any {
+	return doPart(hands, false)
+}
+
+func (solution) Part2(hands []hand) any {
+	return doPart(hands, true)
+}
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[[]hand](2023, 7, solution{})
+	utils.RegisterExamples(2023, 7, examples)
 }
@@ -1,48 +1,61 @@
-package main
+package day8
 
 import (
-	"fmt"
+	"embed"
 	"regexp"
 
 	"aoc/utils"
+	"aoc/utils/numth"
 )
 
-type StartNode struct {
-	node     string
-	offset   int
-	cycleLen int
-}
-
 type Data struct {
 	directions string
 	graph      map[string][]string
 }
 
-func (self *Data) traverseFrom(node string, startSteps int, allZ bool) (int, string) {
-	steps := startSteps
+// Where a ghost is: its node and how far into the directions it is
+type Ghost struct {
+	node string
+	pos  int
+}
 
-	endCond := func() bool {
-		if allZ {
-			return node == "ZZZ"
-		}
-		return node[len(node)-1] == 'Z'
+func (self *Data) step(g Ghost) Ghost {
+	opts := self.graph[g.node]
+	next := opts[1]
+	if self.directions[g.pos] == 'L' {
+		next = opts[0]
 	}
+	return Ghost{next, (g.pos + 1) % len(self.directions)}
+}
 
-	for ; !endCond() || steps == startSteps; steps++ {
-		opts := self.graph[node]
-		next := self.directions[steps%len(self.directions)]
+func (self *Data) traverseFrom(node string) int {
+	steps := 0
+	for g := (Ghost{node, 0}); g.node != "ZZZ"; g = self.step(g) {
+		steps++
+	}
+	return steps
+}
 
-		if next == 'L' {
-			node = opts[0]
+// The steps a ghost from start is on a Z node at: the ones in once, and from
+// mu on every step in one of cycle
+func (self *Data) zSteps(start string) (once []int, cycle []numth.Congruence, mu int) {
+	id := func(g Ghost) Ghost { return g }
+	c := utils.FindCycle(Ghost{start, 0}, self.step, id)
+	for i := 0; i < c.Mu+c.Lambda; i++ {
+		if node := c.At(i).node; node[len(node)-1] != 'Z' {
+			continue
+		}
+		if i < c.Mu {
+			once = append(once, i)
 		} else {
-			node = opts[1]
+			cycle = append(cycle, numth.Congruence{Rem: i % c.Lambda, Mod: c.Lambda})
 		}
 	}
-	return steps - startSteps, node
+	return once, cycle, c.Mu
 }
 
-func parseGraph(fname string) Data {
-	lines := utils.ReadLines(fname)
+func parseGraph(input string) Data {
+	lines := utils.NonEmptyLines(input)
 
 	data := Data{
 		lines[0],
@@ -57,36 +70,96 @@ func parseGraph(fname string) Data {
 	return data
 }
 
-func p1(fname string) {
-	data := parseGraph(fname)
-	steps, _ := data.traverseFrom("AAA", 0, true)
-	fmt.Println("p1:", steps)
+type solution struct{}
+
+func (solution) Parse(input string) Data {
+	return parseGraph(input)
+}
+
+func (solution) Part1(data Data) any {
+	return data.traverseFrom("AAA")
 }
 
-func p2(fname string) {
-	data := parseGraph(fname)
-	var startNodes []StartNode
+func (solution) Part2(data Data) any {
+	var onces [][]int
+	var cycles [][]numth.Congruence
+	var mus []int
 	for node := range data.graph {
 		if node[len(node)-1] == 'A' {
-			sn := StartNode{node, 0, 0}
-			off, endCond := data.traverseFrom(node, 0, false)
-			sn.offset = off
-			cycle, _ := data.traverseFrom(endCond, off, false)
-			sn.cycleLen = cycle
-			startNodes = append(startNodes, sn)
+			once, cycle, mu := data.zSteps(node)
+			onces = append(onces, once)
+			cycles = append(cycles, cycle)
+			mus = append(mus, mu)
+		}
+	}
+
+	onZ := func(g, steps int) bool {
+		for _, s := range onces[g] {
+			if s == steps {
+				return true
+			}
+		}
+		if steps < mus[g] {
+			return false
+		}
+		for _, c := range cycles[g] {
+			if steps%c.Mod == c.Rem {
+				return true
+			}
+		}
+		return false
+	}
+
+	best := -1
+	try := func(steps int) {
+		if best < 0 || steps < best {
+			best = steps
+		}
+	}
+
+	// before every ghost is in its cycle, they can only meet on some ghost's
+	// one-off Z step
+	for _, once := range onces {
+		for _, steps := range once {
+			all := true
+			for g := range onces {
+				all = all && onZ(g, steps)
+			}
+			if all {
+				try(steps)
+			}
 		}
 	}
 
-	// turns out, the inputs are nice and prime so this just works
-	tot := 1
-	for _, sn := range startNodes {
-		tot *= sn.offset / len(data.directions)
+	// after, on the steps of a Z node in each cycle at once
+	minSteps := 0
+	for _, mu := range mus {
+		minSteps = utils.Max(minSteps, mu)
+	}
+	var combine func(g int, acc numth.Congruence)
+	combine = func(g int, acc numth.Congruence) {
+		if g == len(cycles) {
+			try(acc.AtLeast(minSteps))
+			return
+		}
+		for _, c := range cycles[g] {
+			if both, ok := numth.CRT(acc, c); ok {
+				combine(g+1, both)
+			}
+		}
 	}
-	fmt.Println("p2:", tot*len(data.directions))
+	combine(0, numth.Congruence{Mod: 1})
+
+	if best < 0 {
+		panic("ghosts never all reach Z nodes together")
+	}
+	return best
 }
 
-func main() {
-	fname := "~/sync/dev/aoc_inputs/2023/8/input.txt"
-	// p1(fname)
-	p2(fname)
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[Data](2023, 8, solution{})
+	utils.RegisterExamples(2023, 8, examples)
 }
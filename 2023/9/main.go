@@ -1,14 +1,14 @@
-package main
+package day9
 
 import (
-	"fmt"
+	"embed"
 	"regexp"
 
 	"aoc/utils"
 )
 
-func parseSequences(fname string) [][]int {
-	lines := utils.ReadLines(fname)
+func parseSequences(input string) [][]int {
+	lines := utils.NonEmptyLines(input)
 	re := regexp.MustCompile("[^\\s]+")
 
 	seqs := make([][]int, len(lines))
@@ -29,13 +29,11 @@ func seqDone(seq []int) bool {
 }
 
 func extrapolate(seq []int) int {
-	fmt.Println()
 	curr := make([]int, len(seq))
 	copy(curr, seq)
 	var lastNums []int
 
 	for !seqDone(curr) {
-		fmt.Println(curr)
 		lastNums = append(lastNums, curr[len(curr)-1])
 		next := make([]int, len(curr)-1)
 		for i := 0; i < len(curr)-1; i++ {
@@ -49,19 +47,35 @@ func extrapolate(seq []int) int {
 		lastNums[i] += lastLast
 		lastLast = lastNums[i]
 	}
-	fmt.Println(lastNums)
 	return lastNums[0]
 }
 
-func main() {
-	fname := "~/sync/dev/aoc_inputs/2023/9/input.txt"
-	seqs := parseSequences(fname)
-	p1 := 0
-	p2 := 0
+type solution struct{}
+
+func (solution) Parse(input string) [][]int {
+	return parseSequences(input)
+}
+
+func (solution) Part1(seqs [][]int) any {
+	tot := 0
+	for _, s := range seqs {
+		tot += extrapolate(s)
+	}
+	return tot
+}
+
+func (solution) Part2(seqs [][]int) any {
+	tot := 0
 	for _, s := range seqs {
-		p1 += extrapolate(s)
-		p2 += extrapolate(utils.Reversed(s))
+		tot += extrapolate(utils.Reversed(s))
 	}
-	fmt.Println("p1:", p1)
-	fmt.Println("p2:", p2)
+	return tot
+}
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[[][]int](2023, 9, solution{})
+	utils.RegisterExamples(2023, 9, examples)
 }
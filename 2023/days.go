@@ -0,0 +1,25 @@
+// Package y2023 registers every Go day of 2023 with the solution registry
+package y2023
+
+import (
+	_ "aoc/2023/10"
+	_ "aoc/2023/11"
+	_ "aoc/2023/12"
+	_ "aoc/2023/13"
+	_ "aoc/2023/14"
+	_ "aoc/2023/15"
+	_ "aoc/2023/16"
+	_ "aoc/2023/17"
+	_ "aoc/2023/18"
+	_ "aoc/2023/19"
+	_ "aoc/2023/2"
+	_ "aoc/2023/20"
+	_ "aoc/2023/21"
+	_ "aoc/2023/3"
+	_ "aoc/2023/4"
+	_ "aoc/2023/5"
+	_ "aoc/2023/6"
+	_ "aoc/2023/7"
+	_ "aoc/2023/8"
+	_ "aoc/2023/9"
+)
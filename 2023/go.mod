@@ -1,7 +0,0 @@
-module aoc
-
-go 1.20
-
-require aoc/utils v0.0.0-unpublished
-
-replace aoc/utils v0.0.0-unpublished => ../go_utils
@@ -0,0 +1,13 @@
+package main
+
+import (
+	"os"
+
+	_ "aoc/2021"
+	_ "aoc/2023"
+	"aoc/utils"
+)
+
+func main() {
+	os.Exit(utils.Main(os.Args[1:]))
+}
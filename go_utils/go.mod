@@ -1,3 +0,0 @@
-module aoc/utils
-
-go 1.20
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+const ANSWERS_FILE = "answers.json"
+
+// Expected part 1 and 2 answers of a day keyed by input variant. An empty
+// answer hasn't been recorded
+type DayAnswers map[string][2]string
+
+// Expected answers of a year keyed by day
+type YearAnswers map[int]DayAnswers
+
+// Where a year's answers live, next to its inputs
+func (self *InputLocator) AnswersPath(year int) string {
+	return filepath.Join(self.Root, strconv.Itoa(year), ANSWERS_FILE)
+}
+
+// Load answers from fname. A missing file has no answers
+func LoadAnswers(fname string) (YearAnswers, error) {
+	answers := make(YearAnswers)
+	buf, err := os.ReadFile(fname)
+	if errors.Is(err, fs.ErrNotExist) {
+		return answers, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(buf, &answers); err != nil {
+		return nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	return answers, nil
+}
+
+func SaveAnswers(fname string, answers YearAnswers) error {
+	buf, err := json.MarshalIndent(answers, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fname), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(fname, append(buf, '\n'), 0o644)
+}
+
+// Set the answer to part n (1 or 2) of a day's variant
+func (self YearAnswers) Record(day int, variant string, n int, ans string) {
+	if self[day] == nil {
+		self[day] = make(DayAnswers)
+	}
+	a := self[day][variant]
+	a[n-1] = ans
+	self[day][variant] = a
+}
+
+// Variants with recorded answers, in order
+func (self DayAnswers) Variants() []string {
+	var vs []string
+	for v := range self {
+		vs = append(vs, v)
+	}
+	sort.Strings(vs)
+	return vs
+}
+
+// Solve both parts of input and describe every answer that differs from exp
+func (self *Day) Check(input string, exp [2]string) []string {
+	var diffs []string
+	for p := 1; p <= 2; p++ {
+		if exp[p-1] == "" {
+			continue
+		}
+		if ans := FormatAnswer(self.Solve(p, input)); ans != exp[p-1] {
+			diffs = append(diffs, fmt.Sprintf("p%d expected %s got %s", p, exp[p-1], ans))
+		}
+	}
+	return diffs
+}
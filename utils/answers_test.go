@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnswersRoundTrip(t *testing.T) {
+	fname := filepath.Join(t.TempDir(), "2023", ANSWERS_FILE)
+	answers := make(YearAnswers)
+	answers.Record(8, INPUT_VARIANT, 1, "6")
+	answers.Record(8, INPUT_VARIANT, 2, "6")
+	answers.Record(8, "example", 2, "multi\nline")
+
+	if err := SaveAnswers(fname, answers); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadAnswers(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(answers, loaded) {
+		t.Fatalf("Round trip expected %v got %v", answers, loaded)
+	}
+}
+
+func TestLoadAnswersMissing(t *testing.T) {
+	answers, err := LoadAnswers(filepath.Join(t.TempDir(), ANSWERS_FILE))
+	if err != nil || len(answers) != 0 {
+		t.Fatalf("Missing answers expected empty got %v (%v)", answers, err)
+	}
+}
+
+func TestDayCheck(t *testing.T) {
+	d, ok := Lookup(1, 1)
+	if !ok {
+		t.Fatalf("Test day not registered")
+	}
+	input := "1\n2\n3\n"
+
+	if diffs := d.Check(input, [2]string{"6", "3"}); len(diffs) != 0 {
+		t.Fatalf("Expected no diffs got %v", diffs)
+	}
+	if diffs := d.Check(input, [2]string{"", "3"}); len(diffs) != 0 {
+		t.Fatalf("Unrecorded answer should be skipped got %v", diffs)
+	}
+
+	diffs := d.Check(input, [2]string{"7", "3"})
+	if len(diffs) != 1 || !strings.HasPrefix(diffs[0], "p1") {
+		t.Fatalf("Expected a p1 diff got %v", diffs)
+	}
+}
@@ -0,0 +1,53 @@
+// Package aoctest holds the helpers day and year tests check their answers
+// with, kept out of utils so the aoc command doesn't link the testing package
+package aoctest
+
+import (
+	"errors"
+	"io/fs"
+	"strconv"
+	"testing"
+
+	"aoc/utils"
+)
+
+// Run every registered day of a year against the answers recorded next to its
+// inputs, with a subtest per day and variant. Missing inputs are skipped
+func CheckAnswers(t *testing.T, year int) {
+	loc, err := utils.NewInputLocator("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fname := loc.AnswersPath(year)
+	answers, err := utils.LoadAnswers(fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(answers) == 0 {
+		t.Skipf("no answers recorded in %s", fname)
+	}
+
+	for _, d := range utils.Days(year) {
+		d := d
+		t.Run(strconv.Itoa(d.Day), func(t *testing.T) {
+			dayAnswers, ok := answers[d.Day]
+			if !ok {
+				t.Skip("no answers recorded")
+			}
+			for _, v := range dayAnswers.Variants() {
+				v := v
+				t.Run(v, func(t *testing.T) {
+					input, err := loc.Read(d.Year, d.Day, v)
+					if errors.Is(err, fs.ErrNotExist) {
+						t.Skip(err)
+					} else if err != nil {
+						t.Fatal(err)
+					}
+					for _, diff := range d.Check(input, dayAnswers[v]) {
+						t.Error(diff)
+					}
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package aoctest
+
+import (
+	"testing"
+
+	"aoc/utils"
+)
+
+// Run a registered day against each of its embedded examples, with a
+// subtest per example
+func TestExamples(t *testing.T, year, day int) {
+	d, ok := utils.Lookup(year, day)
+	if !ok {
+		t.Fatalf("%d/%d is not registered", year, day)
+	}
+	ex, err := d.Examples()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ex.Answers) == 0 {
+		t.Fatalf("%d/%d has no example answers in %s/%s", year, day, utils.EXAMPLES_DIR, utils.ANSWERS_FILE)
+	}
+
+	for _, v := range ex.Answers.Variants() {
+		v := v
+		t.Run(v, func(t *testing.T) {
+			if ex.Answers[v] == [2]string{} {
+				t.Fatalf("no expected answers for %s in %s/%s", v, utils.EXAMPLES_DIR, utils.ANSWERS_FILE)
+			}
+			input, err := ex.Read(v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, diff := range d.Check(input, ex.Answers[v]) {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package aoctest
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"aoc/utils"
+)
+
+// Echoes the input in part 1 and gives its length in part 2
+type echoSolution struct{}
+
+func (echoSolution) Parse(input string) string {
+	return strings.TrimSpace(input)
+}
+
+func (echoSolution) Part1(s string) any {
+	return s
+}
+
+func (echoSolution) Part2(s string) any {
+	return len(s)
+}
+
+func TestMain(m *testing.M) {
+	utils.Register[string](1, 1, echoSolution{})
+	utils.RegisterExamples(1, 1, fstest.MapFS{
+		"examples/example.txt":  {Data: []byte("abc\n")},
+		"examples/answers.json": {Data: []byte(`{"example": ["abc", "3"]}`)},
+	})
+	os.Exit(m.Run())
+}
+
+func TestExamplesEcho(t *testing.T) {
+	TestExamples(t, 1, 1)
+}
@@ -0,0 +1,284 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// How many times to run each stage, like hyperfine's --warmup and --max-runs
+type BenchOptions struct {
+	Warmup int `json:"warmup"`
+	Runs   int `json:"runs"`
+}
+
+var DEFAULT_BENCH = BenchOptions{Warmup: 1, Runs: 50}
+
+// Timing and allocations of a benchmarked function. Allocations are per run
+type BenchStats struct {
+	Runs   int           `json:"runs"`
+	Mean   time.Duration `json:"mean_ns"`
+	Stddev time.Duration `json:"stddev_ns"`
+	Min    time.Duration `json:"min_ns"`
+	Max    time.Duration `json:"max_ns"`
+	Allocs uint64        `json:"allocs"`
+	Bytes  uint64        `json:"bytes"`
+}
+
+// Stats of one stage of a day: parse, p1 or p2
+type BenchResult struct {
+	Day   int        `json:"day"`
+	Stage string     `json:"stage"`
+	Stats BenchStats `json:"stats"`
+}
+
+// Everything benchmarked in one aoc bench run
+type BenchReport struct {
+	Year      int           `json:"year"`
+	Commit    string        `json:"commit"`
+	Dirty     bool          `json:"dirty"`
+	Time      time.Time     `json:"time"`
+	GoVersion string        `json:"go_version"`
+	Options   BenchOptions  `json:"options"`
+	Results   []BenchResult `json:"results"`
+}
+
+// Benchmark fn. setup runs untimed before every run and its result is passed
+// to fn, so fn can get fresh state each run
+func Bench[T any](opts BenchOptions, setup func() T, fn func(T)) BenchStats {
+	for i := 0; i < opts.Warmup; i++ {
+		fn(setup())
+	}
+
+	times := make([]time.Duration, opts.Runs)
+	var allocs, bytes uint64
+	var before, after runtime.MemStats
+	for i := range times {
+		in := setup()
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		fn(in)
+		times[i] = time.Since(start)
+		runtime.ReadMemStats(&after)
+		allocs += after.Mallocs - before.Mallocs
+		bytes += after.TotalAlloc - before.TotalAlloc
+	}
+
+	stats := durationStats(times)
+	if opts.Runs > 0 {
+		stats.Allocs = allocs / uint64(opts.Runs)
+		stats.Bytes = bytes / uint64(opts.Runs)
+	}
+	return stats
+}
+
+// Mean, sample stddev, min and max of times
+func durationStats(times []time.Duration) BenchStats {
+	stats := BenchStats{Runs: len(times)}
+	if len(times) == 0 {
+		return stats
+	}
+
+	sum := 0.0
+	stats.Min, stats.Max = times[0], times[0]
+	for _, t := range times {
+		sum += float64(t)
+		if t < stats.Min {
+			stats.Min = t
+		}
+		if t > stats.Max {
+			stats.Max = t
+		}
+	}
+	mean := sum / float64(len(times))
+	stats.Mean = time.Duration(mean)
+
+	if len(times) > 1 {
+		sq := 0.0
+		for _, t := range times {
+			sq += (float64(t) - mean) * (float64(t) - mean)
+		}
+		stats.Stddev = time.Duration(math.Sqrt(sq / float64(len(times)-1)))
+	}
+	return stats
+}
+
+// Benchmark parsing input and solving each part from a fresh parse
+func (self *Day) Bench(input string, opts BenchOptions) []BenchResult {
+	noSetup := func() string { return input }
+	results := []BenchResult{{
+		Day:   self.Day,
+		Stage: "parse",
+		Stats: Bench(opts, noSetup, func(in string) { self.Parse(in) }),
+	}}
+
+	for p := 1; p <= 2; p++ {
+		p := p
+		parse := func() any { return self.Parse(input) }
+		results = append(results, BenchResult{
+			Day:   self.Day,
+			Stage: fmt.Sprintf("p%d", p),
+			Stats: Bench(opts, parse, func(in any) { self.Part(p, in) }),
+		})
+	}
+	return results
+}
+
+// Format like run_year.sh: us below a millisecond, ms below a second
+func FormatDuration(d time.Duration) string {
+	s := d.Seconds()
+	switch {
+	case s < 0.001:
+		return fmt.Sprintf("%.3f us", s*1e6)
+	case s < 1:
+		return fmt.Sprintf("%.3f ms", s*1e3)
+	default:
+		return fmt.Sprintf("%.3f s", s)
+	}
+}
+
+// Format a byte count with a binary unit
+func FormatBytes(b uint64) string {
+	units := []string{"B", "KiB", "MiB", "GiB"}
+	f := float64(b)
+	i := 0
+	for f >= 1024 && i < len(units)-1 {
+		f /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%d B", b)
+	}
+	return fmt.Sprintf("%.1f %s", f, units[i])
+}
+
+// The stats of a day's stage, if benchmarked
+func (self *BenchReport) Find(day int, stage string) (BenchStats, bool) {
+	for _, res := range self.Results {
+		if res.Day == day && res.Stage == stage {
+			return res.Stats, true
+		}
+	}
+	return BenchStats{}, false
+}
+
+// Relative change of the mean since base, 0.1 is 10% slower
+func (self BenchStats) Change(base BenchStats) float64 {
+	if base.Mean == 0 {
+		return 0
+	}
+	return float64(self.Mean-base.Mean) / float64(base.Mean)
+}
+
+// Results whose mean is slower than in base by more than threshold
+func Regressions(report, base BenchReport, threshold float64) []BenchResult {
+	var slower []BenchResult
+	for _, res := range report.Results {
+		if b, ok := base.Find(res.Day, res.Stage); ok && res.Stats.Change(b) > threshold {
+			slower = append(slower, res)
+		}
+	}
+	return slower
+}
+
+const (
+	benchHeader = "%-12s │ %-12s │ %-12s │ %-12s │ %-12s │ %-5s │ %-9s │ %-10s"
+	benchRow    = "%12s │ %12s │ %12s │ %12s │ %12s │ %5d │ %9d │ %10s"
+	benchRule   = "─────────────┼──────────────┼──────────────┼──────────────┼──────────────┼───────┼───────────┼───────────"
+)
+
+// Prints bench results in the layout of run_year.sh. With a baseline each row
+// also shows the change of its mean, Red past the threshold and Green when as
+// much faster
+type BenchTable struct {
+	W         io.Writer
+	Baseline  *BenchReport
+	Threshold float64
+}
+
+func (self *BenchTable) Header() {
+	fmt.Fprintf(self.W, benchHeader, "Day", "Mean", "Stddev", "Min", "Max", "Runs", "Allocs", "Bytes")
+	if self.Baseline != nil {
+		fmt.Fprintf(self.W, " │ vs %s", benchKey(self.Baseline.Commit, self.Baseline.Dirty))
+	}
+	fmt.Fprintln(self.W)
+	fmt.Fprint(self.W, benchRule)
+	if self.Baseline != nil {
+		fmt.Fprint(self.W, "┼──────────")
+	}
+	fmt.Fprintln(self.W)
+}
+
+func (self *BenchTable) Row(res BenchResult) {
+	s := res.Stats
+	fmt.Fprintf(self.W, benchRow,
+		fmt.Sprintf("%d %s", res.Day, res.Stage),
+		FormatDuration(s.Mean),
+		FormatDuration(s.Stddev),
+		FormatDuration(s.Min),
+		FormatDuration(s.Max),
+		s.Runs,
+		s.Allocs,
+		FormatBytes(s.Bytes),
+	)
+	if self.Baseline != nil {
+		fmt.Fprint(self.W, " │ ", self.formatChange(res))
+	}
+	fmt.Fprintln(self.W)
+}
+
+func (self *BenchTable) formatChange(res BenchResult) string {
+	base, ok := self.Baseline.Find(res.Day, res.Stage)
+	if !ok {
+		return fmt.Sprintf("%8s", "new")
+	}
+	change := res.Stats.Change(base)
+	out := fmt.Sprintf("%+7.1f%%", change*100)
+	switch {
+	case change > self.Threshold:
+		return Red(out)
+	case change < -self.Threshold:
+		return Green(out)
+	default:
+		return out
+	}
+}
+
+func (self *BenchTable) Footer(results []BenchResult) {
+	var total time.Duration
+	for _, res := range results {
+		total += res.Stats.Mean
+	}
+	width := utf8.RuneCountInString(benchRule)
+	if self.Baseline != nil {
+		width += utf8.RuneCountInString("┼──────────")
+	}
+	fmt.Fprintln(self.W, strings.Repeat("─", width))
+	fmt.Fprintf(self.W, "Total (mean): %s\n", FormatDuration(total))
+}
+
+func SaveBenchReport(fname string, report BenchReport) error {
+	buf, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fname, append(buf, '\n'), 0o644)
+}
+
+func LoadBenchReport(fname string) (BenchReport, error) {
+	var report BenchReport
+	buf, err := os.ReadFile(fname)
+	if err != nil {
+		return report, err
+	}
+	if err := json.Unmarshal(buf, &report); err != nil {
+		return report, fmt.Errorf("%s: %w", fname, err)
+	}
+	return report, nil
+}
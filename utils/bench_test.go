@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationStats(t *testing.T) {
+	stats := durationStats([]time.Duration{2, 4, 4, 4, 5, 5, 7, 9})
+	if stats.Runs != 8 || stats.Mean != 5 || stats.Min != 2 || stats.Max != 9 {
+		t.Fatalf("Stats expected 8 runs mean 5 min 2 max 9 got %+v", stats)
+	}
+	// sample stddev of the above is sqrt(32/7)
+	if stats.Stddev != 2 {
+		t.Fatalf("Stddev expected 2 got %v", stats.Stddev)
+	}
+	if empty := durationStats(nil); empty.Runs != 0 || empty.Mean != 0 {
+		t.Fatalf("Empty stats expected zero got %+v", empty)
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	cases := []struct {
+		d   time.Duration
+		exp string
+	}{
+		{1500 * time.Nanosecond, "1.500 us"},
+		{2500 * time.Microsecond, "2.500 ms"},
+		{1500 * time.Millisecond, "1.500 s"},
+	}
+	for _, c := range cases {
+		if s := FormatDuration(c.d); s != c.exp {
+			t.Fatalf("FormatDuration(%v) expected %s got %s", c.d, c.exp, s)
+		}
+	}
+}
+
+func TestDayBench(t *testing.T) {
+	d, _ := Lookup(1, 1)
+	results := d.Bench("1\n2\n3\n", BenchOptions{Warmup: 1, Runs: 3})
+	if len(results) != 3 {
+		t.Fatalf("Expected parse, p1 and p2 results got %v", results)
+	}
+	for i, stage := range []string{"parse", "p1", "p2"} {
+		if results[i].Stage != stage || results[i].Stats.Runs != 3 {
+			t.Fatalf("Result %d expected %s with 3 runs got %+v", i, stage, results[i])
+		}
+	}
+}
+
+func TestRegressions(t *testing.T) {
+	stats := func(mean time.Duration) BenchStats { return BenchStats{Runs: 1, Mean: mean} }
+	base := BenchReport{Results: []BenchResult{
+		{17, "p1", stats(100)},
+		{17, "p2", stats(100)},
+		{12, "p2", stats(100)},
+	}}
+	report := BenchReport{Results: []BenchResult{
+		{17, "p1", stats(105)},
+		{17, "p2", stats(150)},
+		{12, "p2", stats(50)},
+		{21, "p1", stats(500)},
+	}}
+
+	slower := Regressions(report, base, 0.1)
+	if len(slower) != 1 || slower[0].Day != 17 || slower[0].Stage != "p2" {
+		t.Fatalf("Regressions expected 17 p2 got %v", slower)
+	}
+	if c := report.Results[2].Stats.Change(base.Results[2].Stats); c != -0.5 {
+		t.Fatalf("Change expected -0.5 got %v", c)
+	}
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Directory at the top of the git tree holding saved bench reports as
+// <year>/<commit>.json. It's gitignored
+const BENCH_DIR = ".bench"
+
+// Mean slowdown, as a fraction, past which a stage counts as regressed
+const DEFAULT_THRESHOLD = 0.1
+
+// Saved bench reports keyed by year and commit
+type BenchHistory struct {
+	Dir string
+}
+
+func git(args ...string) (string, error) {
+	out, err := exec.Command("git", args...).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return strings.TrimSpace(string(out)), err
+}
+
+// The checked out commit and whether the tree has uncommitted changes
+func GitCommit() (string, bool, error) {
+	commit, err := git("rev-parse", "--short", "HEAD")
+	if err != nil {
+		return "", false, err
+	}
+	status, err := git("status", "--porcelain")
+	return commit, status != "", err
+}
+
+// Resolve any git revision, e.g. HEAD~1 or main, to the commit its report is
+// saved under
+func ResolveCommit(rev string) (string, error) {
+	return git("rev-parse", "--short", rev)
+}
+
+// History in dir, or in BENCH_DIR at the top of the git tree when dir is empty
+func NewBenchHistory(dir string) (BenchHistory, error) {
+	if dir != "" {
+		return BenchHistory{ExpandUser(dir)}, nil
+	}
+	root, err := git("rev-parse", "--show-toplevel")
+	if err != nil {
+		return BenchHistory{}, err
+	}
+	return BenchHistory{filepath.Join(root, BENCH_DIR)}, nil
+}
+
+// Reports of dirty trees are kept apart from the commit they're based on
+func benchKey(commit string, dirty bool) string {
+	if dirty {
+		return commit + "-dirty"
+	}
+	return commit
+}
+
+func (self *BenchHistory) Path(year int, commit string, dirty bool) string {
+	return filepath.Join(self.Dir, strconv.Itoa(year), benchKey(commit, dirty)+".json")
+}
+
+// Save a report under its commit. Stages the report didn't run are kept from
+// an earlier report of the same commit
+func (self *BenchHistory) Save(report BenchReport) error {
+	fname := self.Path(report.Year, report.Commit, report.Dirty)
+	if err := os.MkdirAll(filepath.Dir(fname), 0o755); err != nil {
+		return err
+	}
+
+	old, err := LoadBenchReport(fname)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	for _, res := range old.Results {
+		if _, ok := report.Find(res.Day, res.Stage); !ok {
+			report.Results = append(report.Results, res)
+		}
+	}
+	sort.SliceStable(report.Results, func(i, j int) bool {
+		return report.Results[i].Day < report.Results[j].Day
+	})
+	return SaveBenchReport(fname, report)
+}
+
+// The report saved for a clean checkout of commit
+func (self *BenchHistory) Load(year int, commit string) (BenchReport, error) {
+	return LoadBenchReport(self.Path(year, commit, false))
+}
+
+// The most recent report of a year that isn't of report's own commit and
+// dirtiness. ok is false when there is none
+func (self *BenchHistory) Latest(report BenchReport) (latest BenchReport, ok bool, err error) {
+	ents, err := os.ReadDir(filepath.Join(self.Dir, strconv.Itoa(report.Year)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return latest, false, nil
+	}
+	if err != nil {
+		return latest, false, err
+	}
+
+	own := benchKey(report.Commit, report.Dirty) + ".json"
+	for _, ent := range ents {
+		if ent.IsDir() || ent.Name() == own || filepath.Ext(ent.Name()) != ".json" {
+			continue
+		}
+		r, err := LoadBenchReport(filepath.Join(self.Dir, strconv.Itoa(report.Year), ent.Name()))
+		if err != nil {
+			return latest, false, err
+		}
+		if !ok || r.Time.After(latest.Time) {
+			latest, ok = r, true
+		}
+	}
+	return latest, ok, nil
+}
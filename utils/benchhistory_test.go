@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBenchHistory(t *testing.T) {
+	h, err := NewBenchHistory(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC)
+	reports := []BenchReport{
+		{Year: 2023, Commit: "aaaaaaa", Time: start},
+		{Year: 2023, Commit: "bbbbbbb", Time: start.Add(time.Hour)},
+		{Year: 2023, Commit: "bbbbbbb", Dirty: true, Time: start.Add(2 * time.Hour)},
+	}
+	for _, r := range reports {
+		if err := h.Save(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	latest, ok, err := h.Latest(reports[2])
+	if err != nil || !ok || latest.Commit != "bbbbbbb" || latest.Dirty {
+		t.Fatalf("Latest for a dirty tree expected clean bbbbbbb got %+v %v (%v)", latest, ok, err)
+	}
+	latest, ok, err = h.Latest(reports[1])
+	if err != nil || !ok || !latest.Dirty {
+		t.Fatalf("Latest for bbbbbbb expected the dirty run got %+v %v (%v)", latest, ok, err)
+	}
+
+	loaded, err := h.Load(2023, "aaaaaaa")
+	if err != nil || !loaded.Time.Equal(start) {
+		t.Fatalf("Load expected aaaaaaa got %+v (%v)", loaded, err)
+	}
+	if _, ok, _ := h.Latest(BenchReport{Year: 2021}); ok {
+		t.Fatalf("Expected no history for 2021")
+	}
+}
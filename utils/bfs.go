@@ -0,0 +1,122 @@
+package utils
+
+// Breadth first search from starts, with every step costing 1. Works like
+// Dijkstra: the search stops at the first state for which goal is true, or
+// explores everything reachable when goal is nil
+func BFS[S comparable](starts []S, neighbors func(S) []S, goal func(S) bool) Paths[S] {
+	paths := Paths[S]{Dist: make(map[S]int), Preds: make(map[S]S)}
+	queue := make([]S, 0, len(starts))
+	for _, s := range starts {
+		if _, ok := paths.Dist[s]; !ok {
+			paths.Dist[s] = 0
+			queue = append(queue, s)
+		}
+	}
+
+	for head := 0; head < len(queue); head++ {
+		curr := queue[head]
+		if goal != nil && goal(curr) {
+			paths.End, paths.Found = curr, true
+			return paths
+		}
+		for _, next := range neighbors(curr) {
+			if _, ok := paths.Dist[next]; ok {
+				continue
+			}
+			paths.Dist[next] = paths.Dist[curr] + 1
+			paths.Preds[next] = curr
+			queue = append(queue, next)
+		}
+	}
+	return paths
+}
+
+// Distances of a BFS over a grid
+type GridBFS struct {
+	// Steps from the start to each cell, -1 where it can't be reached
+	Dist IntGrid
+	// Reached cells in order of distance, the start first
+	Reachable []V2
+	// How many cells are reached in an even or odd number of steps
+	Even, Odd int
+}
+
+// Whether v was reached
+func (self *GridBFS) Reached(v V2) bool {
+	return self.Dist.At(v) >= 0
+}
+
+// Cells that can be reached in exactly steps steps when moving back and forth
+// is allowed: those within steps that have its parity
+func (self *GridBFS) Within(steps int) int {
+	count := 0
+	for _, v := range self.Reachable {
+		d := self.Dist.At(v)
+		if d > steps {
+			break
+		}
+		if d%2 == steps%2 {
+			count++
+		}
+	}
+	return count
+}
+
+// Breadth first search from start through orthogonally adjacent cells for
+// which passable is true. The start is always reached
+func (self *Grid[T]) BFS(start V2, passable func(T) bool) GridBFS {
+	res := GridBFS{Dist: self.newDist(), Reachable: []V2{start}}
+	res.Dist.Set(start, 0)
+
+	for head := 0; head < len(res.Reachable); head++ {
+		curr := res.Reachable[head]
+		d := res.Dist.At(curr)
+		if d%2 == 0 {
+			res.Even++
+		} else {
+			res.Odd++
+		}
+
+		for it := self.NeighborIter(curr, Cardinal); it.Next(); {
+			if res.Dist.At(it.V) >= 0 || !passable(self.At(it.V)) {
+				continue
+			}
+			res.Dist.Set(it.V, d+1)
+			res.Reachable = append(res.Reachable, it.V)
+		}
+	}
+	return res
+}
+
+// Regions of orthogonally connected cells for which passable is true, in
+// order of their first cell from the top left
+func (self *Grid[T]) FloodFill(passable func(T) bool) [][]V2 {
+	seen := self.newDist()
+	var regions [][]V2
+
+	for cell := self.Iter(); cell.Next(); {
+		if seen.At(cell.V) >= 0 || !passable(self.At(cell.V)) {
+			continue
+		}
+
+		seen.Set(cell.V, len(regions))
+		region := []V2{cell.V}
+		for head := 0; head < len(region); head++ {
+			for it := self.NeighborIter(region[head], Cardinal); it.Next(); {
+				if seen.At(it.V) < 0 && passable(self.At(it.V)) {
+					seen.Set(it.V, len(regions))
+					region = append(region, it.V)
+				}
+			}
+		}
+		regions = append(regions, region)
+	}
+	return regions
+}
+
+// A grid of the same size filled with -1
+func (self *Grid[T]) newDist() IntGrid {
+	dist := NewGrid[int](self.H(), self.W())
+	dist.Fill(-1)
+	return dist
+}
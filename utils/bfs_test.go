@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestBFS(t *testing.T) {
+	// states are ints, and from n you can go to n+1 and 2n
+	neighbors := func(n int) []int { return []int{n + 1, 2 * n} }
+	paths := BFS([]int{1}, neighbors, func(n int) bool { return n == 10 })
+	if !paths.Found || paths.Dist[10] != 4 {
+		t.Fatalf("Steps to 10 expected 4 got %d", paths.Dist[10])
+	}
+	if path := paths.Path(); len(path) != 5 || path[0] != 1 || path[4] != 10 {
+		t.Fatalf("Path expected 5 states from 1 to 10 got %v", path)
+	}
+}
+
+func TestGridBFS(t *testing.T) {
+	grid := ParseByteGrid([]string{
+		"...........",
+		".....###.#.",
+		".###.##..#.",
+		"..#.#...#..",
+		"....#.#....",
+		".##..S####.",
+		".##..#...#.",
+		".......##..",
+		".##.#.####.",
+		".####.##.#.",
+		"...........",
+	})
+	open := func(c byte) bool { return c != '#' }
+	bfs := grid.BFS(V2{X: 5, Y: 5}, open)
+
+	if n := bfs.Within(6); n != 16 {
+		t.Fatalf("Plots reached in 6 steps expected 16 got %d", n)
+	}
+	if n := bfs.Within(64); n != 41 || bfs.Even != 41 {
+		t.Fatalf("Plots reached in 64 steps expected 41 got %d (%d even)", n, bfs.Even)
+	}
+	if bfs.Even+bfs.Odd != len(bfs.Reachable) || bfs.Reached(V2{X: 5, Y: 1}) {
+		t.Fatalf("Parity counts expected to add up to %d got %d + %d", len(bfs.Reachable), bfs.Even, bfs.Odd)
+	}
+	if d := bfs.Dist.At(V2{X: 0, Y: 0}); d != 10 {
+		t.Fatalf("Distance to the corner expected 10 got %d", d)
+	}
+}
+
+func TestFloodFill(t *testing.T) {
+	grid := ParseIntGrid([]string{
+		"2199943210",
+		"3987894921",
+		"9856789892",
+		"8767896789",
+		"9899965678",
+	}, "")
+	regions := grid.FloodFill(func(h int) bool { return h != 9 })
+
+	var sizes []int
+	for _, r := range regions {
+		sizes = append(sizes, len(r))
+	}
+	if !SliceEq(sizes, []int{3, 9, 14, 9}) {
+		t.Fatalf("Basin sizes expected [3 9 14 9] got %v", sizes)
+	}
+}
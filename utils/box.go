@@ -0,0 +1,131 @@
+package utils
+
+// A box in N dimensions: the points whose coordinate on each axis is in that
+// axis' set. Boxes are never changed in place, so they can be copied freely
+type Box[T numeric] struct {
+	axes []IntervalSet[T]
+}
+
+// The box spanning an interval on each axis
+func NewBox[T numeric](axes ...Interval[T]) Box[T] {
+	b := Box[T]{make([]IntervalSet[T], len(axes))}
+	for i, iv := range axes {
+		b.axes[i] = NewIntervalSet(iv)
+	}
+	return b
+}
+
+func (self *Box[T]) Dims() int {
+	return len(self.axes)
+}
+
+// The values the box spans on axis i
+func (self *Box[T]) Axis(i int) IntervalSet[T] {
+	return self.axes[i]
+}
+
+// Whether the box has no points, which is when any axis is empty
+func (self *Box[T]) Empty() bool {
+	for i := range self.axes {
+		if self.axes[i].Empty() {
+			return true
+		}
+	}
+	return len(self.axes) == 0
+}
+
+// Number of points in the box
+func (self *Box[T]) Volume() T {
+	if len(self.axes) == 0 {
+		return 0
+	}
+	var vol T = 1
+	for i := range self.axes {
+		vol *= self.axes[i].Len()
+	}
+	return vol
+}
+
+func (self *Box[T]) Contains(p []T) bool {
+	for i := range self.axes {
+		if !self.axes[i].Contains(p[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// The box with axis i replaced
+func (self *Box[T]) withAxis(i int, set IntervalSet[T]) Box[T] {
+	res := Box[T]{append([]IntervalSet[T](nil), self.axes...)}
+	res.axes[i] = set
+	return res
+}
+
+// Split into the part whose values on axis are in pass and the rest
+func (self *Box[T]) Split(axis int, pass IntervalSet[T]) (Box[T], Box[T]) {
+	in := self.axes[axis].Intersect(pass)
+	out := self.axes[axis].Difference(pass)
+	return self.withAxis(axis, in), self.withAxis(axis, out)
+}
+
+// Split into the part below v on axis and the part from v on
+func (self *Box[T]) SplitAt(axis int, v T) (Box[T], Box[T]) {
+	below, above := self.axes[axis].SplitAt(v)
+	return self.withAxis(axis, below), self.withAxis(axis, above)
+}
+
+// Points in both boxes
+func (self *Box[T]) Intersect(other Box[T]) Box[T] {
+	res := Box[T]{make([]IntervalSet[T], len(self.axes))}
+	for i := range self.axes {
+		res.axes[i] = self.axes[i].Intersect(other.axes[i])
+	}
+	return res
+}
+
+// Points in the box but not in other, as disjoint boxes
+func (self *Box[T]) Subtract(other Box[T]) []Box[T] {
+	if overlap := self.Intersect(other); overlap.Empty() {
+		return []Box[T]{*self}
+	}
+
+	// peel off the part outside other one axis at a time, keeping what's left
+	// inside it on the axes done so far
+	var res []Box[T]
+	rest := *self
+	for i := range self.axes {
+		in, out := rest.Split(i, other.axes[i])
+		if !out.Empty() {
+			res = append(res, out)
+		}
+		rest = in
+	}
+	return res
+}
+
+// Total volume of boxes that don't overlap
+func Volume[T numeric](boxes []Box[T]) T {
+	var vol T
+	for i := range boxes {
+		vol += boxes[i].Volume()
+	}
+	return vol
+}
+
+// Volume of the union of boxes, which may overlap
+func UnionVolume[T numeric](boxes []Box[T]) T {
+	var disjoint []Box[T]
+	for _, b := range boxes {
+		pieces := []Box[T]{b}
+		for _, d := range disjoint {
+			var next []Box[T]
+			for i := range pieces {
+				next = append(next, pieces[i].Subtract(d)...)
+			}
+			pieces = next
+		}
+		disjoint = append(disjoint, pieces...)
+	}
+	return Volume(disjoint)
+}
@@ -0,0 +1,53 @@
+package utils
+
+import "testing"
+
+func TestBox(t *testing.T) {
+	b := NewBox(Interval[int]{0, 4}, Interval[int]{0, 3})
+	if b.Dims() != 2 || b.Volume() != 12 || !b.Contains([]int{3, 2}) || b.Contains([]int{4, 0}) {
+		t.Fatalf("Box %v wrong volume or contents", b)
+	}
+
+	below, above := b.SplitAt(0, 1)
+	if below.Volume() != 3 || above.Volume() != 9 {
+		t.Fatalf("SplitAt expected volumes 3 and 9 got %d and %d", below.Volume(), above.Volume())
+	}
+	if b.Volume() != 12 {
+		t.Fatalf("SplitAt changed the box")
+	}
+
+	in, out := b.Split(1, NewIntervalSet(Interval[int]{-5, 1}, Interval[int]{2, 10}))
+	if in.Volume() != 8 || out.Volume() != 4 || in.Contains([]int{0, 1}) || !out.Contains([]int{0, 1}) {
+		t.Fatalf("Split expected volumes 8 and 4 got %d and %d", in.Volume(), out.Volume())
+	}
+
+	o := NewBox(Interval[int]{2, 6}, Interval[int]{1, 2})
+	if i := b.Intersect(o); i.Volume() != 2 {
+		t.Fatalf("Intersect expected volume 2 got %d", i.Volume())
+	}
+	diff := b.Subtract(o)
+	if v := Volume(diff); v != 10 {
+		t.Fatalf("Subtract expected volume 10 got %d", v)
+	}
+	for _, d := range diff {
+		if d.Contains([]int{2, 1}) || d.Contains([]int{3, 1}) {
+			t.Fatalf("Subtract kept a point of the other box in %v", d)
+		}
+	}
+
+	if e := b.Intersect(NewBox(Interval[int]{5, 6}, Interval[int]{0, 3})); !e.Empty() || e.Volume() != 0 {
+		t.Fatalf("Intersect of disjoint boxes expected empty")
+	}
+}
+
+func TestUnionVolume(t *testing.T) {
+	boxes := []Box[int]{
+		NewBox(Interval[int]{0, 4}, Interval[int]{0, 4}, Interval[int]{0, 4}),
+		NewBox(Interval[int]{2, 6}, Interval[int]{2, 6}, Interval[int]{2, 6}),
+		NewBox(Interval[int]{1, 3}, Interval[int]{1, 3}, Interval[int]{1, 3}),
+	}
+	// two 64 cubes sharing a 2x2x2 corner, the third inside the first
+	if v := UnionVolume(boxes); v != 120 {
+		t.Fatalf("UnionVolume expected 120 got %d", v)
+	}
+}
@@ -0,0 +1,357 @@
+package utils
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const usage = `usage: aoc <command> [flags]
+
+commands:
+  run    run registered solutions
+  bench  benchmark parsing and each part of registered solutions
+  new    create a new day from the template
+`
+
+// Entrypoint for the aoc command. Returns the exit code
+func Main(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return 2
+	}
+
+	var err error
+	switch args[0] {
+	case "run":
+		err = runCmd(args[1:])
+	case "bench":
+		err = benchCmd(args[1:])
+	case "new":
+		err = newCmd(args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", args[0], usage)
+		return 2
+	}
+
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, Red("error:"), err)
+		return 1
+	}
+	return 0
+}
+
+func runCmd(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	year := fs.Int("year", 0, "year to run (required)")
+	day := fs.Int("day", 0, "day to run, all days when unset")
+	part := fs.Int("part", 0, "part to run, all parts when unset")
+	input := fs.String("input", "", "input file, only valid with --day")
+	inputs := fs.String("inputs", "", "inputs directory, overrides $"+INPUTS_ENV+" and the config file")
+	variant := fs.String("variant", INPUT_VARIANT, "input variant, e.g. example or example2")
+	record := fs.Bool("record", false, "save the answers as the expected answers")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *year == 0 {
+		return fmt.Errorf("--year is required, have %v", Years())
+	}
+	if *input != "" && *day == 0 {
+		return errors.New("--input requires --day")
+	}
+	if *input != "" && *record {
+		return errors.New("can't --record answers of an --input file")
+	}
+	if *part < 0 || *part > 2 {
+		return fmt.Errorf("no part %d", *part)
+	}
+
+	days := Days(*year)
+	if *day != 0 {
+		d, ok := Lookup(*year, *day)
+		if !ok {
+			return fmt.Errorf("%d/%d is not registered", *year, *day)
+		}
+		days = []Day{d}
+	}
+	if len(days) == 0 {
+		return fmt.Errorf("no days registered for %d", *year)
+	}
+
+	loc, err := NewInputLocator(*inputs)
+	if err != nil {
+		return err
+	}
+	answers, err := LoadAnswers(loc.AnswersPath(*year))
+	if err != nil {
+		return err
+	}
+
+	for _, d := range days {
+		fmt.Println(Blue(fmt.Sprintf("%d/%d", d.Year, d.Day)))
+		var buf string
+		var embeddedExp [2]string
+		embedded := false
+		if *input != "" {
+			var raw []byte
+			raw, err = os.ReadFile(ExpandUser(*input))
+			buf = string(raw)
+		} else {
+			buf, embeddedExp, embedded, err = readVariant(&loc, &d, *variant)
+		}
+		if err != nil && len(days) == 1 {
+			return err
+		} else if err != nil {
+			// keep going so one missing input doesn't stop the whole year
+			fmt.Println(Red(err.Error()))
+			continue
+		}
+
+		for p := 1; p <= 2; p++ {
+			if *part != 0 && *part != p {
+				continue
+			}
+			exp := ""
+			if *input == "" {
+				exp = answers[d.Day][*variant][p-1]
+			}
+			if exp == "" && embedded {
+				// examples often only work for one part, and the ones
+				// that don't are left without an answer, as Check skips them
+				if exp = embeddedExp[p-1]; exp == "" {
+					fmt.Printf("p%d: skipped, no expected answer for %s\n", p, *variant)
+					continue
+				}
+			}
+
+			start := time.Now()
+			res, err := d.TrySolve(p, buf)
+			elapsed := time.Since(start)
+			if err != nil && len(days) == 1 {
+				return err
+			} else if err != nil {
+				// like a missing input, a day that panics doesn't stop the rest
+				fmt.Println(Red(err.Error()))
+				continue
+			}
+			ans := FormatAnswer(res)
+			if *record && ans != "" {
+				answers.Record(d.Day, *variant, p, ans)
+			}
+			fmt.Printf("p%d: %s %s\n", p, formatChecked(ans, exp), Blue(elapsed.String()))
+		}
+	}
+
+	if *record {
+		return SaveAnswers(loc.AnswersPath(*year), answers)
+	}
+	return nil
+}
+
+func benchCmd(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	year := fs.Int("year", 0, "year to benchmark (required)")
+	day := fs.Int("day", 0, "day to benchmark, all days when unset")
+	inputs := fs.String("inputs", "", "inputs directory, overrides $"+INPUTS_ENV+" and the config file")
+	variant := fs.String("variant", INPUT_VARIANT, "input variant, e.g. example or example2")
+	warmup := fs.Int("warmup", DEFAULT_BENCH.Warmup, "untimed runs before measuring")
+	runs := fs.Int("runs", DEFAULT_BENCH.Runs, "timed runs of each stage")
+	out := fs.String("json", "", "also write results as JSON to this file")
+	history := fs.String("history", "", "bench history directory, default "+BENCH_DIR+" at the top of the git tree")
+	baseline := fs.String("baseline", "", "git revision to compare against, default the latest saved run")
+	threshold := fs.Float64("threshold", DEFAULT_THRESHOLD*100, "percent slowdown of a mean flagged as a regression")
+	save := fs.Bool("save", true, "save the results to the history under the current commit")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *year == 0 {
+		return fmt.Errorf("--year is required, have %v", Years())
+	}
+	if *warmup < 0 || *runs < 1 {
+		return errors.New("--warmup can't be negative and --runs has to be positive")
+	}
+	// timings of examples say little, only runs of the real inputs are kept
+	useHistory := *variant == INPUT_VARIANT
+
+	days := Days(*year)
+	if *day != 0 {
+		d, ok := Lookup(*year, *day)
+		if !ok {
+			return fmt.Errorf("%d/%d is not registered", *year, *day)
+		}
+		days = []Day{d}
+	}
+	if len(days) == 0 {
+		return fmt.Errorf("no days registered for %d", *year)
+	}
+
+	loc, err := NewInputLocator(*inputs)
+	if err != nil {
+		return err
+	}
+
+	report := BenchReport{
+		Year:      *year,
+		Time:      time.Now().UTC(),
+		GoVersion: runtime.Version(),
+		Options:   BenchOptions{Warmup: *warmup, Runs: *runs},
+	}
+	table := BenchTable{W: os.Stdout, Threshold: *threshold / 100}
+	var hist BenchHistory
+	if useHistory {
+		if hist, err = NewBenchHistory(*history); err != nil {
+			return err
+		}
+		if report.Commit, report.Dirty, err = GitCommit(); err != nil {
+			return err
+		}
+		base, err := loadBaseline(&hist, report, *baseline)
+		if err != nil {
+			return err
+		}
+		table.Baseline = base
+	} else if *baseline != "" {
+		return errors.New("--baseline only compares runs of the real inputs")
+	}
+
+	table.Header()
+	for _, d := range days {
+		buf, _, _, err := readVariant(&loc, &d, *variant)
+		if err != nil {
+			fmt.Println(Red(err.Error()))
+			continue
+		}
+		for _, res := range d.Bench(buf, report.Options) {
+			table.Row(res)
+			report.Results = append(report.Results, res)
+		}
+	}
+	table.Footer(report.Results)
+
+	if table.Baseline != nil {
+		slower := Regressions(report, *table.Baseline, table.Threshold)
+		msg := fmt.Sprintf("%d regressions over %.0f%% vs %s", len(slower), *threshold, table.Baseline.Commit)
+		if len(slower) > 0 {
+			fmt.Println(Red(msg))
+		} else {
+			fmt.Println(Green(msg))
+		}
+	}
+	if useHistory && *save && len(report.Results) > 0 {
+		if err := hist.Save(report); err != nil {
+			return err
+		}
+	}
+	if *out != "" {
+		return SaveBenchReport(*out, report)
+	}
+	return nil
+}
+
+// The report to compare against: the one saved for rev, or when rev is empty
+// the latest other one. nil when there is none yet
+func loadBaseline(hist *BenchHistory, report BenchReport, rev string) (*BenchReport, error) {
+	if rev == "" {
+		base, ok, err := hist.Latest(report)
+		if !ok {
+			return nil, err
+		}
+		return &base, err
+	}
+
+	commit, err := ResolveCommit(rev)
+	if err != nil {
+		return nil, err
+	}
+	base, err := hist.Load(report.Year, commit)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("no bench of %d saved for %s", report.Year, commit)
+	}
+	return &base, err
+}
+
+func newCmd(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	year := fs.Int("year", 0, "year of the new day (required)")
+	day := fs.Int("day", 0, "the new day (required)")
+	root := fs.String("root", "", "module root, default the nearest go.mod above the working directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *year == 0 || *day == 0 {
+		return errors.New("--year and --day are required")
+	}
+	if *day < 1 || *day > 25 {
+		return fmt.Errorf("no day %d in advent", *day)
+	}
+	if *root == "" {
+		r, err := FindModuleRoot(".")
+		if err != nil {
+			return err
+		}
+		*root = r
+	}
+
+	files, err := Scaffold(*root, *year, *day)
+	if len(files) > 0 {
+		fmt.Println(relPaths(*root, files))
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println(Green(fmt.Sprintf("created %d/%d", *year, *day)),
+		"- fill in examples/example.txt and its answers, then solve until go test passes")
+	return nil
+}
+
+// Read a day's input variant. Examples missing from the inputs directory
+// come from the ones embedded in the day, along with their expected answers,
+// and embedded says so
+func readVariant(loc *InputLocator, d *Day, variant string) (input string, exp [2]string, embedded bool, err error) {
+	input, err = loc.Read(d.Year, d.Day, variant)
+	if err == nil || !isExample(variant) || !errors.Is(err, fs.ErrNotExist) {
+		return input, exp, false, err
+	}
+	ex, exErr := d.Examples()
+	if exErr != nil {
+		return "", exp, false, exErr
+	}
+	input, exErr = ex.Read(variant)
+	if exErr != nil {
+		return "", exp, false, err
+	}
+	return input, ex.Answers[variant], true, nil
+}
+
+// Color an answer by whether it matches the expected one, if known
+func formatChecked(ans, exp string) string {
+	out := ans
+	if ans == "" {
+		out = "-"
+	} else if strings.Contains(ans, "\n") {
+		out = "\n" + ans
+	}
+
+	switch {
+	case exp == "":
+		return out
+	case ans == exp:
+		return Green(out)
+	default:
+		return Red(out) + " expected " + exp
+	}
+}
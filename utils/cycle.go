@@ -0,0 +1,74 @@
+package utils
+
+// A cycle in the states start, step(start), step(step(start)), ...: from step
+// Mu on the states repeat every Lambda steps
+type Cycle[S any] struct {
+	Mu, Lambda int
+	start      S
+	step       func(S) S
+	// states from the start up to the repeat, if the search kept them
+	states []S
+}
+
+// The state after n steps, found without simulating past the first repeat
+func (self *Cycle[S]) At(n int) S {
+	if n >= self.Mu {
+		n = self.Mu + (n-self.Mu)%self.Lambda
+	}
+	if n < len(self.states) {
+		return self.states[n]
+	}
+	s := self.start
+	for i := 0; i < n; i++ {
+		s = self.step(s)
+	}
+	return s
+}
+
+// Find the cycle of step from start by remembering every state by its key.
+// step must not change the state it's given, and two states are the same
+// when their keys are. Never returns if the states don't repeat
+func FindCycle[S any, K comparable](start S, step func(S) S, key func(S) K) Cycle[S] {
+	seen := make(map[K]int)
+	var states []S
+	s := start
+	for i := 0; ; i++ {
+		k := key(s)
+		if j, ok := seen[k]; ok {
+			return Cycle[S]{Mu: j, Lambda: i - j, start: start, step: step, states: states}
+		}
+		seen[k] = i
+		states = append(states, s)
+		s = step(s)
+	}
+}
+
+// FindCycle in constant memory with Brent's algorithm, for when there are too
+// many states to keep. Steps about twice as often, and At has to simulate
+func FindCycleBrent[S any, K comparable](start S, step func(S) S, key func(S) K) Cycle[S] {
+	// find lambda: the hare runs ahead while the tortoise waits at powers of 2
+	power, lambda := 1, 1
+	tortoise, hare := start, step(start)
+	for key(tortoise) != key(hare) {
+		if power == lambda {
+			tortoise = hare
+			power *= 2
+			lambda = 0
+		}
+		hare = step(hare)
+		lambda++
+	}
+
+	// find mu: with the hare lambda ahead they meet where the cycle starts
+	tortoise, hare = start, start
+	for i := 0; i < lambda; i++ {
+		hare = step(hare)
+	}
+	mu := 0
+	for key(tortoise) != key(hare) {
+		tortoise = step(tortoise)
+		hare = step(hare)
+		mu++
+	}
+	return Cycle[S]{Mu: mu, Lambda: lambda, start: start, step: step}
+}
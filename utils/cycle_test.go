@@ -0,0 +1,46 @@
+package utils
+
+import "testing"
+
+func TestFindCycle(t *testing.T) {
+	// 0 1 2 3 4 5 6 7 3 4 5 6 7 ...
+	step := func(x int) int {
+		if x == 7 {
+			return 3
+		}
+		return x + 1
+	}
+	id := func(x int) int { return x }
+
+	for name, c := range map[string]Cycle[int]{
+		"FindCycle":      FindCycle(0, step, id),
+		"FindCycleBrent": FindCycleBrent(0, step, id),
+	} {
+		if c.Mu != 3 || c.Lambda != 5 {
+			t.Fatalf("%s expected mu 3 lambda 5 got mu %d lambda %d", name, c.Mu, c.Lambda)
+		}
+		for n, expected := range map[int]int{0: 0, 2: 2, 7: 7, 8: 3, 1_000_000_000: 5} {
+			if s := c.At(n); s != expected {
+				t.Fatalf("%s At(%d) expected %d got %d", name, n, expected, s)
+			}
+		}
+	}
+}
+
+func TestFindCyclePure(t *testing.T) {
+	// a cycle from the start
+	step := func(x int) int { return (x + 4) % 10 }
+	id := func(x int) int { return x }
+
+	for name, c := range map[string]Cycle[int]{
+		"FindCycle":      FindCycle(2, step, id),
+		"FindCycleBrent": FindCycleBrent(2, step, id),
+	} {
+		if c.Mu != 0 || c.Lambda != 5 {
+			t.Fatalf("%s expected mu 0 lambda 5 got mu %d lambda %d", name, c.Mu, c.Lambda)
+		}
+		if s := c.At(13); s != 4 {
+			t.Fatalf("%s At(13) expected 4 got %d", name, s)
+		}
+	}
+}
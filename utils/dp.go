@@ -0,0 +1,101 @@
+package utils
+
+import "sort"
+
+// A recursive function that remembers its results. f gets the memoized
+// function to recurse through
+type Memo[K comparable, V any] struct {
+	cache map[K]V
+	f     func(rec func(K) V, k K) V
+}
+
+func NewMemo[K comparable, V any](f func(rec func(K) V, k K) V) *Memo[K, V] {
+	return &Memo[K, V]{cache: make(map[K]V), f: f}
+}
+
+// The result for k, computed only the first time it's asked for
+func (self *Memo[K, V]) Get(k K) V {
+	if v, ok := self.cache[k]; ok {
+		return v
+	}
+	v := self.f(self.Get, k)
+	self.cache[k] = v
+	return v
+}
+
+// How many results are remembered
+func (self *Memo[K, V]) Len() int {
+	return len(self.cache)
+}
+
+// A multiset: how many times each key was added
+type Counter[K comparable] map[K]int
+
+func NewCounter[K comparable](keys ...K) Counter[K] {
+	c := make(Counter[K])
+	for _, k := range keys {
+		c[k]++
+	}
+	return c
+}
+
+// Count k n more times
+func (self Counter[K]) Add(k K, n int) {
+	self[k] += n
+}
+
+// Add every count of other
+func (self Counter[K]) Merge(other Counter[K]) {
+	for k, n := range other {
+		self[k] += n
+	}
+}
+
+func (self Counter[K]) Total() int {
+	tot := 0
+	for _, n := range self {
+		tot += n
+	}
+	return tot
+}
+
+type Count[K comparable] struct {
+	Key K
+	N   int
+}
+
+// The n keys with the highest counts, highest first, or all of them when n is
+// negative. Keys with the same count come in no particular order
+func (self Counter[K]) MostCommon(n int) []Count[K] {
+	counts := make([]Count[K], 0, len(self))
+	for k, c := range self {
+		counts = append(counts, Count[K]{k, c})
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].N > counts[j].N })
+	if n >= 0 && n < len(counts) {
+		counts = counts[:n]
+	}
+	return counts
+}
+
+// How many ways there are to reach each state, advanced a step at a time
+type StateCounter[S comparable] struct {
+	Counts Counter[S]
+}
+
+// Start with one way to reach each of start
+func NewStateCounter[S comparable](start ...S) *StateCounter[S] {
+	return &StateCounter[S]{NewCounter(start...)}
+}
+
+// Move every state through next, which emits the states it leads to. Each
+// of those is reached in as many ways as the state it came from, and states
+// that emit nothing are dropped
+func (self *StateCounter[S]) Step(next func(s S, emit func(S))) {
+	counts := make(Counter[S])
+	for s, n := range self.Counts {
+		n := n
+		next(s, func(to S) { counts[to] += n })
+	}
+	self.Counts = counts
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMemo(t *testing.T) {
+	calls := 0
+	fib := NewMemo(func(rec func(int) int, n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return rec(n-1) + rec(n-2)
+	})
+
+	if v := fib.Get(90); v != 2880067194370816120 {
+		t.Fatalf("fib(90) expected 2880067194370816120 got %d", v)
+	}
+	if calls != 91 || fib.Len() != 91 {
+		t.Fatalf("expected 91 calls and results got %d and %d", calls, fib.Len())
+	}
+	fib.Get(50)
+	if calls != 91 {
+		t.Fatalf("expected fib(50) to be remembered, got %d calls", calls)
+	}
+}
+
+func TestCounter(t *testing.T) {
+	c := NewCounter([]byte("abracadabra")...)
+	if c['a'] != 5 || c['b'] != 2 || c['z'] != 0 || c.Total() != 11 {
+		t.Fatalf("counts expected a 5 b 2 z 0 of 11 got %v", c)
+	}
+
+	c.Add('z', 4)
+	c.Merge(NewCounter[byte]('c', 'c', 'd'))
+	expected := []Count[byte]{{'a', 5}, {'z', 4}}
+	if mc := c.MostCommon(2); !reflect.DeepEqual(mc, expected) {
+		t.Fatalf("MostCommon(2) expected %v got %v", expected, mc)
+	}
+	if mc := c.MostCommon(-1); len(mc) != 6 || mc[5].N != 2 {
+		t.Fatalf("MostCommon(-1) expected 6 keys ending with a count of 2 got %v", mc)
+	}
+}
+
+func TestStateCounter(t *testing.T) {
+	// paths down a Pascal's triangle, piling up in column 3
+	sc := NewStateCounter(0)
+	for i := 0; i < 5; i++ {
+		sc.Step(func(col int, emit func(int)) {
+			emit(col)
+			if col < 3 {
+				emit(col + 1)
+			}
+		})
+	}
+	expected := Counter[int]{0: 1, 1: 5, 2: 10, 3: 10}
+	if !reflect.DeepEqual(sc.Counts, expected) {
+		t.Fatalf("expected %v got %v", expected, sc.Counts)
+	}
+}
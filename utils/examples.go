@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// Days keep their examples in this directory as <variant>.txt next to an
+// answers.json holding DayAnswers, and embed it with
+//
+//	//go:embed examples
+//	var examples embed.FS
+const EXAMPLES_DIR = "examples"
+
+// A day's examples with their expected answers
+type Examples struct {
+	fsys    fs.FS
+	Answers DayAnswers
+}
+
+// Load the examples directory from fsys
+func LoadExamples(fsys fs.FS) (Examples, error) {
+	ex := Examples{fsys, make(DayAnswers)}
+	buf, err := fs.ReadFile(fsys, path.Join(EXAMPLES_DIR, ANSWERS_FILE))
+	if errors.Is(err, fs.ErrNotExist) {
+		return ex, nil
+	}
+	if err != nil {
+		return ex, err
+	}
+	if err := json.Unmarshal(buf, &ex.Answers); err != nil {
+		return ex, fmt.Errorf("%s/%s: %w", EXAMPLES_DIR, ANSWERS_FILE, err)
+	}
+	return ex, nil
+}
+
+func (self *Examples) Read(variant string) (string, error) {
+	buf, err := fs.ReadFile(self.fsys, path.Join(EXAMPLES_DIR, variant+".txt"))
+	return string(buf), err
+}
+
+// Attach a day's embedded examples. Call after Register
+func RegisterExamples(year, day int, fsys fs.FS) {
+	d, ok := registry[year][day]
+	if !ok {
+		panic(fmt.Sprintf("%d/%d has to be registered before its examples", year, day))
+	}
+	d.examples = fsys
+	registry[year][day] = d
+}
+
+// The day's embedded examples, empty when it has none
+func (self *Day) Examples() (Examples, error) {
+	if self.examples == nil {
+		return Examples{Answers: make(DayAnswers)}, nil
+	}
+	return LoadExamples(self.examples)
+}
+
+func isExample(variant string) bool {
+	return strings.HasPrefix(variant, "example")
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadExamples(t *testing.T) {
+	ex, err := LoadExamples(countExamples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vs := ex.Answers.Variants(); len(vs) != 2 || vs[0] != "example" || vs[1] != "example2" {
+		t.Fatalf("Variants expected [example example2] got %v", vs)
+	}
+	input, err := ex.Read("example2")
+	if err != nil || input != "10\n20\n" {
+		t.Fatalf("Read expected 10 20 got %q (%v)", input, err)
+	}
+
+	bad := fstest.MapFS{"examples/answers.json": {Data: []byte(`{"example": `)}}
+	if _, err := LoadExamples(bad); err == nil {
+		t.Fatalf("Expected error for malformed answers")
+	}
+}
+
+func TestReadVariantEmbedded(t *testing.T) {
+	loc := InputLocator{Root: t.TempDir()}
+	d, _ := Lookup(1, 1)
+
+	input, exp, embedded, err := readVariant(&loc, &d, "example")
+	if err != nil || !embedded || input != "1\n2\n3\n" || exp != [2]string{"6", "3"} {
+		t.Fatalf("Embedded example expected 1 2 3 [6 3] got %q %v %v (%v)", input, exp, embedded, err)
+	}
+	if _, _, _, err := readVariant(&loc, &d, INPUT_VARIANT); err == nil {
+		t.Fatalf("Expected error for missing input")
+	}
+}
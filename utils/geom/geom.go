@@ -0,0 +1,144 @@
+// Package geom works with simple polygons on the integer lattice, given as
+// their vertices in order. The closing edge from the last vertex back to the
+// first is implied
+package geom
+
+import (
+	"aoc/utils"
+	"aoc/utils/numth"
+)
+
+// Iterates the edges of a polygon, the closing one last
+//
+//	for it := geom.Edges(poly); it.Next(); {
+//		fmt.Println(it.A, it.B)
+//	}
+type EdgeIter struct {
+	A, B utils.V2
+	poly []utils.V2
+	i    int
+}
+
+func Edges(poly []utils.V2) EdgeIter {
+	return EdgeIter{poly: poly}
+}
+
+func (self *EdgeIter) Next() bool {
+	if self.i >= len(self.poly) {
+		return false
+	}
+	self.A = self.poly[self.i]
+	self.B = self.poly[(self.i+1)%len(self.poly)]
+	self.i++
+	return true
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Twice the area of the polygon by the shoelace formula. Always a whole
+// number, unlike the area itself
+func DoubleArea(poly []utils.V2) int {
+	// https://en.wikipedia.org/wiki/Shoelace_formula
+	area := 0
+	for it := Edges(poly); it.Next(); {
+		area += it.A.X*it.B.Y - it.B.X*it.A.Y
+	}
+	return abs(area)
+}
+
+// Lattice points on the edges of the polygon
+func BoundaryPoints(poly []utils.V2) int {
+	n := 0
+	for it := Edges(poly); it.Next(); {
+		n += numth.GCD(it.B.X-it.A.X, it.B.Y-it.A.Y)
+	}
+	return n
+}
+
+// Lattice points strictly inside the polygon, by Pick's theorem
+func InteriorPoints(poly []utils.V2) int {
+	// https://en.wikipedia.org/wiki/Pick%27s_theorem: A = I + B/2 - 1
+	return (DoubleArea(poly) - BoundaryPoints(poly) + 2) / 2
+}
+
+// Lattice points inside the polygon or on its edges
+func EnclosedPoints(poly []utils.V2) int {
+	return InteriorPoints(poly) + BoundaryPoints(poly)
+}
+
+// Whether p lies on the segment from a to b
+func onSegment(a, b, p utils.V2) bool {
+	cross := (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+	return cross == 0 && between(p.X, a.X, b.X) && between(p.Y, a.Y, b.Y)
+}
+
+// Whether v is from a to b inclusive, in either order. Compares exactly, as
+// utils.Min and Max round through float64
+func between(v, a, b int) bool {
+	if a > b {
+		a, b = b, a
+	}
+	return a <= v && v <= b
+}
+
+// Whether p lies on an edge of the polygon, vertices included
+func OnBoundary(poly []utils.V2, p utils.V2) bool {
+	for it := Edges(poly); it.Next(); {
+		if onSegment(it.A, it.B, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether p is inside the polygon or on its boundary
+func Contains(poly []utils.V2, p utils.V2) bool {
+	// cast a ray from p towards +x and count the edges it crosses. Edges are
+	// half-open in y so a ray through a vertex counts it once
+	inside := false
+	for it := Edges(poly); it.Next(); {
+		a, b := it.A, it.B
+		if onSegment(a, b, p) {
+			return true
+		}
+		if (a.Y > p.Y) == (b.Y > p.Y) {
+			continue
+		}
+		if a.Y > b.Y {
+			a, b = b, a
+		}
+		// the edge crosses the row of p to its right
+		if (b.X-a.X)*(p.Y-a.Y)-(b.Y-a.Y)*(p.X-a.X) > 0 {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// A move of Len steps in direction Dir
+type Step struct {
+	Dir utils.V2
+	Len int
+}
+
+// The polygon traced by following steps from start. Collinear steps give
+// extra vertices, which none of the functions here mind
+func FromSteps(start utils.V2, steps []Step) []utils.V2 {
+	poly := []utils.V2{start}
+	pos := start
+	for _, s := range steps {
+		vec := s.Dir.Mul(s.Len)
+		pos = pos.Add(&vec)
+		poly = append(poly, pos)
+	}
+	// the steps usually end back at the start, which is already the first
+	if len(poly) > 1 && poly[len(poly)-1] == start {
+		poly = poly[:len(poly)-1]
+	}
+	return poly
+}
@@ -0,0 +1,82 @@
+package geom
+
+import (
+	"testing"
+
+	"aoc/utils"
+)
+
+func TestArea(t *testing.T) {
+	square := []utils.V2{{X: 0, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 4}, {X: 0, Y: 4}}
+	if a := DoubleArea(square); a != 32 {
+		t.Fatalf("DoubleArea expected 32 got %d", a)
+	}
+	if b := BoundaryPoints(square); b != 16 {
+		t.Fatalf("BoundaryPoints expected 16 got %d", b)
+	}
+	if i := InteriorPoints(square); i != 9 {
+		t.Fatalf("InteriorPoints expected 9 got %d", i)
+	}
+	if n := EnclosedPoints(square); n != 25 {
+		t.Fatalf("EnclosedPoints expected 25 got %d", n)
+	}
+
+	// clockwise with a diagonal edge and a half-integer area
+	tri := []utils.V2{{X: 0, Y: 0}, {X: 0, Y: 3}, {X: 3, Y: 1}}
+	if a := DoubleArea(tri); a != 9 {
+		t.Fatalf("DoubleArea of a triangle expected 9 got %d", a)
+	}
+	if i := InteriorPoints(tri); i != 3 {
+		t.Fatalf("InteriorPoints of a triangle expected 3 got %d", i)
+	}
+}
+
+func TestContains(t *testing.T) {
+	// a U: the notch from x 2 to 4 down to y 2 is outside
+	poly := []utils.V2{
+		{X: 0, Y: 0}, {X: 6, Y: 0}, {X: 6, Y: 4}, {X: 4, Y: 4},
+		{X: 4, Y: 2}, {X: 2, Y: 2}, {X: 2, Y: 4}, {X: 0, Y: 4},
+	}
+	for _, tc := range []struct {
+		p        utils.V2
+		inside   bool
+		boundary bool
+	}{
+		{utils.V2{X: 0, Y: 0}, true, true},
+		{utils.V2{X: 6, Y: 2}, true, true},
+		{utils.V2{X: 3, Y: 2}, true, true},
+		{utils.V2{X: 1, Y: 2}, true, false},
+		{utils.V2{X: 5, Y: 3}, true, false},
+		{utils.V2{X: 3, Y: 3}, false, false},
+		{utils.V2{X: 7, Y: 2}, false, false},
+		{utils.V2{X: -1, Y: 0}, false, false},
+		{utils.V2{X: 3, Y: 4}, false, false},
+	} {
+		if in := Contains(poly, tc.p); in != tc.inside {
+			t.Fatalf("Contains %v expected %v got %v", tc.p, tc.inside, in)
+		}
+		if on := OnBoundary(poly, tc.p); on != tc.boundary {
+			t.Fatalf("OnBoundary %v expected %v got %v", tc.p, tc.boundary, on)
+		}
+	}
+
+	// past 2^53, where float64 rounds the edge's x
+	far := []utils.V2{{X: 0, Y: 0}, {X: 1<<60 + 1, Y: 0}, {X: 1<<60 + 1, Y: 2}}
+	if p := (utils.V2{X: 1<<60 + 1, Y: 1}); !OnBoundary(far, p) {
+		t.Fatalf("OnBoundary %v expected true", p)
+	}
+}
+
+func TestFromSteps(t *testing.T) {
+	right, down := utils.V2{X: 1}, utils.V2{Y: 1}
+	left, up := utils.V2{X: -1}, utils.V2{Y: -1}
+	poly := FromSteps(utils.V2{X: 1, Y: 1}, []Step{{right, 2}, {right, 1}, {down, 2}, {left, 3}, {up, 2}})
+
+	exp := []utils.V2{{X: 1, Y: 1}, {X: 3, Y: 1}, {X: 4, Y: 1}, {X: 4, Y: 3}, {X: 1, Y: 3}}
+	if !utils.SliceEq(poly, exp) {
+		t.Fatalf("FromSteps expected %v got %v", exp, poly)
+	}
+	if n := EnclosedPoints(poly); n != 12 {
+		t.Fatalf("EnclosedPoints expected 12 got %d", n)
+	}
+}
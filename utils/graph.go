@@ -0,0 +1,139 @@
+package utils
+
+// Shortest paths from the start states of a Dijkstra or AStar search
+type Paths[S comparable] struct {
+	// Distance of every reached state
+	Dist map[S]int
+	// Predecessor of every reached state on a shortest path, starts have none
+	Preds map[S]S
+	// The first goal state reached, if Found
+	End   S
+	Found bool
+}
+
+// States of the shortest path from a start to s, both included. nil if s
+// wasn't reached
+func (self *Paths[S]) PathTo(s S) []S {
+	if _, ok := self.Dist[s]; !ok {
+		return nil
+	}
+	path := []S{s}
+	for {
+		pred, ok := self.Preds[s]
+		if !ok {
+			break
+		}
+		path = append(path, pred)
+		s = pred
+	}
+	return Reversed(path)
+}
+
+// The shortest path to End
+func (self *Paths[S]) Path() []S {
+	if !self.Found {
+		return nil
+	}
+	return self.PathTo(self.End)
+}
+
+// Find shortest paths from starts. neighbors gives the states reachable from a
+// state, and cost the non-negative cost of moving between them. The search
+// stops at the first state for which goal is true, or explores everything
+// reachable when goal is nil
+func Dijkstra[S comparable](
+	starts []S,
+	neighbors func(S) []S,
+	cost func(from, to S) int,
+	goal func(S) bool,
+) Paths[S] {
+	return AStar(starts, neighbors, cost, goal, nil)
+}
+
+// Dijkstra guided by heuristic, an estimate of the remaining cost to a goal.
+// States are never reopened once done, so the heuristic must be consistent:
+// never more than the cost of a move plus the estimate where it leads, and 0
+// at a goal. Only never overestimating isn't enough. A nil heuristic is plain
+// Dijkstra
+func AStar[S comparable](
+	starts []S,
+	neighbors func(S) []S,
+	cost func(from, to S) int,
+	goal func(S) bool,
+	heuristic func(S) int,
+) Paths[S] {
+	paths := Paths[S]{Dist: make(map[S]int), Preds: make(map[S]S)}
+	estimate := func(s S) int {
+		if heuristic == nil {
+			return 0
+		}
+		return heuristic(s)
+	}
+
+	var pq PriorityQueue[S]
+	for _, s := range starts {
+		paths.Dist[s] = 0
+		pq.Push(s, estimate(s))
+	}
+
+	done := make(map[S]bool)
+	for pq.Len() > 0 {
+		curr, _ := pq.Pop()
+		if done[curr] {
+			continue
+		}
+		done[curr] = true
+
+		if goal != nil && goal(curr) {
+			paths.End, paths.Found = curr, true
+			return paths
+		}
+
+		d := paths.Dist[curr]
+		for _, next := range neighbors(curr) {
+			nd := d + cost(curr, next)
+			if old, ok := paths.Dist[next]; ok && old <= nd {
+				continue
+			}
+			paths.Dist[next] = nd
+			paths.Preds[next] = curr
+			pq.Push(next, nd+estimate(next))
+		}
+	}
+	return paths
+}
+
+// A search state on a grid: a position plus whatever the movement rules need
+// to track, like the direction moved in
+type GridState[P comparable] struct {
+	Pos  V2
+	Data P
+}
+
+// Shortest paths over a grid with rules on how to move. next gives the states
+// one cell away that can be reached from a state; ones out of bounds are
+// dropped. Moving costs cost of the cell entered, and cells with a negative
+// cost can't be entered. goal works as in Dijkstra
+func GridDijkstra[T any, P comparable](
+	g *Grid[T],
+	starts []GridState[P],
+	next func(GridState[P]) []GridState[P],
+	cost func(T) int,
+	goal func(GridState[P]) bool,
+) Paths[GridState[P]] {
+	neighbors := func(s GridState[P]) []GridState[P] {
+		ns := next(s)
+		n := 0
+		for _, to := range ns {
+			if g.InBounds(to.Pos) && cost(g.At(to.Pos)) >= 0 {
+				ns[n] = to
+				n++
+			}
+		}
+		return ns[:n]
+	}
+	stepCost := func(_, to GridState[P]) int {
+		return cost(g.At(to.Pos))
+	}
+	return Dijkstra(starts, neighbors, stepCost, goal)
+}
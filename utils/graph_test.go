@@ -0,0 +1,132 @@
+package utils
+
+import (
+	"testing"
+)
+
+var graphEdges = map[string]map[string]int{
+	"a": {"b": 7, "c": 9, "f": 14},
+	"b": {"a": 7, "c": 10, "d": 15},
+	"c": {"a": 9, "b": 10, "d": 11, "f": 2},
+	"d": {"b": 15, "c": 11, "e": 6},
+	"e": {"d": 6, "f": 9},
+	"f": {"a": 14, "c": 2, "e": 9},
+	"x": {},
+}
+
+func graphNeighbors(s string) []string {
+	var ns []string
+	for n := range graphEdges[s] {
+		ns = append(ns, n)
+	}
+	return ns
+}
+
+func graphCost(from, to string) int {
+	return graphEdges[from][to]
+}
+
+func TestDijkstra(t *testing.T) {
+	paths := Dijkstra([]string{"a"}, graphNeighbors, graphCost, func(s string) bool { return s == "e" })
+	if !paths.Found || paths.End != "e" || paths.Dist["e"] != 20 {
+		t.Fatalf("Dijkstra to e expected 20 got %v %v", paths.Found, paths.Dist["e"])
+	}
+	if path := paths.Path(); !SliceEq(path, []string{"a", "c", "f", "e"}) {
+		t.Fatalf("Path expected [a c f e] got %v", path)
+	}
+
+	all := Dijkstra([]string{"a"}, graphNeighbors, graphCost, nil)
+	if all.Found || len(all.Dist) != 6 || all.Dist["d"] != 20 {
+		t.Fatalf("Full search expected 6 states with d at 20 got %v", all.Dist)
+	}
+	if path := all.PathTo("x"); path != nil {
+		t.Fatalf("Unreached state expected no path got %v", path)
+	}
+}
+
+func TestAStarGrid(t *testing.T) {
+	grid := ParseIntGrid([]string{
+		"1163751742",
+		"1381373672",
+		"2136511328",
+		"3694931569",
+		"7463417111",
+		"1319128137",
+		"1359912421",
+		"3125421639",
+		"1293138521",
+		"2311944581",
+	}, "")
+	end := V2{X: grid.W() - 1, Y: grid.H() - 1}
+	neighbors := func(v V2) []V2 { return grid.Neighbors(v, false) }
+	cost := func(_, to V2) int { return grid.At(to) }
+	goal := func(v V2) bool { return v == end }
+	manhattan := func(v V2) int { return IntAbs(end.X-v.X) + IntAbs(end.Y-v.Y) }
+
+	for _, h := range []func(V2) int{nil, manhattan} {
+		paths := AStar([]V2{{}}, neighbors, cost, goal, h)
+		if paths.Dist[end] != 40 {
+			t.Fatalf("Lowest risk expected 40 got %d", paths.Dist[end])
+		}
+		risk := 0
+		for _, v := range paths.Path()[1:] {
+			risk += grid.At(v)
+		}
+		if risk != 40 {
+			t.Fatalf("Path risk expected 40 got %d", risk)
+		}
+	}
+}
+
+func TestGridDijkstra(t *testing.T) {
+	grid := ParseByteGrid([]string{
+		"..#....",
+		".##.##.",
+		"...#...",
+		".#...#.",
+	})
+	// walls can't be entered and every other step costs 1. The payload counts
+	// steps taken without turning, at most maxRun
+	maxRun := 2
+	type run struct {
+		dir V2
+		n   int
+	}
+	next := func(s GridState[run]) []GridState[run] {
+		var ns []GridState[run]
+		for _, d := range []V2{{X: 1}, {X: -1}, {Y: 1}, {Y: -1}} {
+			n := 1
+			if d == s.Data.dir {
+				n = s.Data.n + 1
+			}
+			if n <= maxRun {
+				ns = append(ns, GridState[run]{s.Pos.Add(&d), run{d, n}})
+			}
+		}
+		return ns
+	}
+	cost := func(c byte) int {
+		if c == '#' {
+			return -1
+		}
+		return 1
+	}
+	end := V2{X: 6, Y: 0}
+	goal := func(s GridState[run]) bool { return s.Pos == end }
+	paths := GridDijkstra(&grid, []GridState[run]{{}}, next, cost, goal)
+
+	if !paths.Found || paths.Dist[paths.End] != 12 {
+		t.Fatalf("Path to the top right expected 12 steps got %d", paths.Dist[paths.End])
+	}
+	for _, s := range paths.Path() {
+		if grid.At(s.Pos) == '#' || s.Data.n > maxRun {
+			t.Fatalf("Path entered a wall or ran too long at %v", s)
+		}
+	}
+
+	// the corridors can't be crossed turning every step
+	maxRun = 1
+	if paths := GridDijkstra(&grid, []GridState[run]{{}}, next, cost, goal); paths.Found {
+		t.Fatalf("Expected no path turning every step got %v", paths.Path())
+	}
+}
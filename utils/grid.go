@@ -0,0 +1,297 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A grid of cells stored row by row in one slice
+type Grid[T any] struct {
+	h, w int
+	data []T
+}
+
+type IntGrid = Grid[int]
+
+// A grid of zero values
+func NewGrid[T any](h, w int) Grid[T] {
+	return Grid[T]{h, w, make([]T, h*w)}
+}
+
+// A grid copied from rows, which must all have the same length
+func GridFromRows[T any](rows [][]T) Grid[T] {
+	if len(rows) == 0 {
+		return Grid[T]{}
+	}
+	g := NewGrid[T](len(rows), len(rows[0]))
+	for y, row := range rows {
+		copy(g.Row(y), row)
+	}
+	return g
+}
+
+// Parse every character of lines into a cell
+func ParseGrid[T any](lines []string, parse func(rune) T) Grid[T] {
+	if len(lines) == 0 {
+		return Grid[T]{}
+	}
+	g := NewGrid[T](len(lines), len([]rune(lines[0])))
+	for y, ln := range lines {
+		x := 0
+		for _, c := range ln {
+			g.data[y*g.w+x] = parse(c)
+			x++
+		}
+	}
+	return g
+}
+
+// Parse lines of ASCII characters
+func ParseByteGrid(lines []string) Grid[byte] {
+	if len(lines) == 0 {
+		return Grid[byte]{}
+	}
+	g := NewGrid[byte](len(lines), len(lines[0]))
+	for y, ln := range lines {
+		copy(g.Row(y), ln)
+	}
+	return g
+}
+
+func ParseRuneGrid(lines []string) Grid[rune] {
+	return ParseGrid(lines, func(c rune) rune { return c })
+}
+
+// Parse lines of single digits
+func ParseDigitGrid(lines []string) IntGrid {
+	return ParseGrid(lines, func(c rune) int { return int(c - '0') })
+}
+
+// Parse lines of ints split by sep into a grid, "" splits every digit
+func ParseIntGrid(lines []string, sep string) IntGrid {
+	rows := make([][]int, len(lines))
+	for i, ln := range lines {
+		rows[i] = StrsToInts(strings.Split(ln, sep))
+	}
+	return GridFromRows(rows)
+}
+
+func (self *Grid[T]) H() int {
+	return self.h
+}
+
+func (self *Grid[T]) W() int {
+	return self.w
+}
+
+func (self *Grid[T]) At(v V2) T {
+	return self.data[v.Y*self.w+v.X]
+}
+
+func (self *Grid[T]) Set(v V2, val T) {
+	self.data[v.Y*self.w+v.X] = val
+}
+
+// Pointer to the cell at v, for updating it in place
+func (self *Grid[T]) Ptr(v V2) *T {
+	return &self.data[v.Y*self.w+v.X]
+}
+
+func (self *Grid[T]) InBounds(v V2) bool {
+	return Between(v.Y, 0, self.h) && Between(v.X, 0, self.w)
+}
+
+// Row y, sharing storage with the grid
+func (self *Grid[T]) Row(y int) []T {
+	return self.data[y*self.w : (y+1)*self.w]
+}
+
+// A copy of column x
+func (self *Grid[T]) Col(x int) []T {
+	col := make([]T, self.h)
+	for y := range col {
+		col[y] = self.data[y*self.w+x]
+	}
+	return col
+}
+
+func (self *Grid[T]) Fill(val T) {
+	for i := range self.data {
+		self.data[i] = val
+	}
+}
+
+func (self *Grid[T]) Clone() Grid[T] {
+	return Grid[T]{self.h, self.w, append([]T(nil), self.data...)}
+}
+
+// Iterates the positions of a rectangle of a grid row by row
+//
+//	for it := g.Iter(); it.Next(); {
+//		fmt.Println(it.V, g.At(it.V))
+//	}
+type GridIter struct {
+	V        V2
+	min, max V2
+	started  bool
+}
+
+func (self *GridIter) Next() bool {
+	if !self.started {
+		self.started = true
+		self.V = self.min
+		return self.V.X <= self.max.X && self.V.Y <= self.max.Y
+	}
+	self.V.X++
+	if self.V.X > self.max.X {
+		self.V.X = self.min.X
+		self.V.Y++
+	}
+	return self.V.Y <= self.max.Y
+}
+
+// Iterate every position
+func (self *Grid[T]) Iter() GridIter {
+	return self.IterRegion(V2{}, V2{X: self.w - 1, Y: self.h - 1})
+}
+
+func (self *Grid[T]) IterRow(y int) GridIter {
+	return self.IterRegion(V2{X: 0, Y: y}, V2{X: self.w - 1, Y: y})
+}
+
+func (self *Grid[T]) IterCol(x int) GridIter {
+	return self.IterRegion(V2{X: x, Y: 0}, V2{X: x, Y: self.h - 1})
+}
+
+// Iterate the rectangle between corners min and max, both included and
+// clipped to the grid
+func (self *Grid[T]) IterRegion(min, max V2) GridIter {
+	min = V2{X: Max(min.X, 0), Y: Max(min.Y, 0)}
+	max = V2{X: Min(max.X, self.w-1), Y: Min(max.Y, self.h-1)}
+	return GridIter{min: min, max: max}
+}
+
+// Which neighbors of a cell to visit
+type NeighborType int
+
+const (
+	Cardinal NeighborType = iota
+	Diagonal
+	AllNeighbors
+)
+
+// Iterates the in bounds neighbors of a cell without allocating
+type NeighborIter struct {
+	V         V2
+	positions [8]V2
+	curr, n   int
+}
+
+func (self *NeighborIter) Next() bool {
+	if self.curr >= self.n {
+		return false
+	}
+	self.V = self.positions[self.curr]
+	self.curr++
+	return true
+}
+
+var (
+	cardinalSteps = [4]V2{{X: 1}, {X: -1}, {Y: 1}, {Y: -1}}
+	diagonalSteps = [4]V2{{X: 1, Y: 1}, {X: 1, Y: -1}, {X: -1, Y: 1}, {X: -1, Y: -1}}
+)
+
+// Iterate the neighbors of v, cardinal ones first when visiting all
+//
+//	for it := g.NeighborIter(v, utils.Cardinal); it.Next(); {
+//		fmt.Println(it.V)
+//	}
+func (self *Grid[T]) NeighborIter(v V2, typ NeighborType) NeighborIter {
+	var it NeighborIter
+	add := func(steps *[4]V2) {
+		for i := range steps {
+			nb := v.Add(&steps[i])
+			if self.InBounds(nb) {
+				it.positions[it.n] = nb
+				it.n++
+			}
+		}
+	}
+	if typ != Diagonal {
+		add(&cardinalSteps)
+	}
+	if typ != Cardinal {
+		add(&diagonalSteps)
+	}
+	return it
+}
+
+// In bounds neighbors of v, with diagonal ones if diag
+func (self *Grid[T]) Neighbors(v V2, diag bool) []V2 {
+	typ := Cardinal
+	if diag {
+		typ = AllNeighbors
+	}
+	it := self.NeighborIter(v, typ)
+	return append([]V2(nil), it.positions[:it.n]...)
+}
+
+// The first position from the top left whose cell matches
+func (self *Grid[T]) Find(match func(T) bool) (V2, bool) {
+	for i, c := range self.data {
+		if match(c) {
+			return V2{X: i % self.w, Y: i / self.w}, true
+		}
+	}
+	return V2{}, false
+}
+
+// Every position whose cell matches, row by row
+func (self *Grid[T]) FindAll(match func(T) bool) []V2 {
+	var found []V2
+	for i, c := range self.data {
+		if match(c) {
+			found = append(found, V2{X: i % self.w, Y: i / self.w})
+		}
+	}
+	return found
+}
+
+// The grid as text with the cells at hl in red. Byte and rune cells are
+// printed as characters, anything else separated by spaces
+func (self *Grid[T]) Format(hl ...V2) string {
+	hlSet := NewSet(hl)
+	var bld strings.Builder
+	for it := self.Iter(); it.Next(); {
+		var s string
+		switch c := any(self.At(it.V)).(type) {
+		case byte:
+			s = string(rune(c))
+		case rune:
+			s = string(c)
+		default:
+			s = fmt.Sprint(c)
+			if it.V.X > 0 {
+				bld.WriteByte(' ')
+			}
+		}
+
+		if hlSet.Contains(it.V) {
+			s = Red(s)
+		}
+		bld.WriteString(s)
+		if it.V.X == self.w-1 {
+			bld.WriteByte('\n')
+		}
+	}
+	return bld.String()
+}
+
+func (self Grid[T]) String() string {
+	return self.Format()
+}
+
+// Print the grid with the cells at hl in red
+func (self *Grid[T]) Print(hl ...V2) {
+	fmt.Print(self.Format(hl...))
+}
@@ -0,0 +1,142 @@
+package utils
+
+import "testing"
+
+func TestParseIntGrid(t *testing.T) {
+	gd := ParseIntGrid([]string{"123", "456"}, "")
+	if gd.H() != 2 || gd.W() != 3 {
+		t.Fatalf("Wrong size expected 2x3 got %dx%d", gd.H(), gd.W())
+	}
+	if v := gd.At(V2{X: 2, Y: 1}); v != 6 {
+		t.Fatalf("Wrong value at (2, 1) expected 6 got %d", v)
+	}
+
+	gd = ParseIntGrid([]string{"10,20", "30,40"}, ",")
+	if v := gd.At(V2{X: 0, Y: 1}); v != 30 {
+		t.Fatalf("Wrong value at (0, 1) expected 30 got %d", v)
+	}
+}
+
+func TestGridParse(t *testing.T) {
+	bg := ParseByteGrid([]string{"#.", ".#"})
+	if bg.At(V2{X: 1, Y: 1}) != '#' || bg.At(V2{X: 1, Y: 0}) != '.' {
+		t.Fatalf("ParseByteGrid wrong cells %q", bg.String())
+	}
+	rg := ParseRuneGrid([]string{"αβ", "γδ"})
+	if rg.W() != 2 || rg.At(V2{X: 1, Y: 1}) != 'δ' {
+		t.Fatalf("ParseRuneGrid expected δ at (1, 1) got %q", rg.At(V2{X: 1, Y: 1}))
+	}
+	dg := ParseDigitGrid([]string{"19", "28"})
+	if dg.At(V2{X: 1, Y: 0}) != 9 {
+		t.Fatalf("ParseDigitGrid expected 9 at (1, 0) got %d", dg.At(V2{X: 1, Y: 0}))
+	}
+}
+
+func TestGridSetClone(t *testing.T) {
+	gd := NewGrid[int](2, 3)
+	gd.Fill(1)
+	cl := gd.Clone()
+	gd.Set(V2{X: 2, Y: 1}, 5)
+	*gd.Ptr(V2{X: 0, Y: 0}) += 1
+
+	if s := gd.String(); s != "2 1 1\n1 1 5\n" {
+		t.Fatalf("Grid expected %q got %q", "2 1 1\n1 1 5\n", s)
+	}
+	if s := cl.String(); s != "1 1 1\n1 1 1\n" {
+		t.Fatalf("Clone changed with the grid %q", s)
+	}
+	if r := gd.Row(1); r[2] != 5 {
+		t.Fatalf("Row expected [1 1 5] got %v", r)
+	}
+	if c := gd.Col(0); c[0] != 2 || c[1] != 1 {
+		t.Fatalf("Col expected [2 1] got %v", c)
+	}
+}
+
+func TestGridIter(t *testing.T) {
+	gd := NewGrid[int](3, 4)
+	n := 0
+	for it := gd.Iter(); it.Next(); {
+		if it.V.X != n%4 || it.V.Y != n/4 {
+			t.Fatalf("Iter expected %v got %v", V2{X: n % 4, Y: n / 4}, it.V)
+		}
+		n++
+	}
+	if n != 12 {
+		t.Fatalf("Iter expected 12 cells got %d", n)
+	}
+
+	var col []V2
+	for it := gd.IterCol(3); it.Next(); {
+		col = append(col, it.V)
+	}
+	if len(col) != 3 || col[2] != (V2{X: 3, Y: 2}) {
+		t.Fatalf("IterCol expected 3 cells ending at (3, 2) got %v", col)
+	}
+
+	var region []V2
+	for it := gd.IterRegion(V2{X: 2, Y: -1}, V2{X: 9, Y: 0}); it.Next(); {
+		region = append(region, it.V)
+	}
+	if len(region) != 2 || region[0] != (V2{X: 2}) || region[1] != (V2{X: 3}) {
+		t.Fatalf("IterRegion expected [(2, 0) (3, 0)] got %v", region)
+	}
+
+	empty := Grid[int]{}
+	for it := empty.Iter(); it.Next(); {
+		t.Fatalf("Iter of an empty grid returned %v", it.V)
+	}
+}
+
+func TestGridNeighbors(t *testing.T) {
+	gd := NewGrid[int](3, 3)
+	count := func(v V2, typ NeighborType) int {
+		n := 0
+		for it := gd.NeighborIter(v, typ); it.Next(); {
+			n++
+		}
+		return n
+	}
+
+	for _, tc := range []struct {
+		v        V2
+		typ      NeighborType
+		expected int
+	}{
+		{V2{X: 1, Y: 1}, Cardinal, 4},
+		{V2{X: 1, Y: 1}, Diagonal, 4},
+		{V2{X: 1, Y: 1}, AllNeighbors, 8},
+		{V2{}, Cardinal, 2},
+		{V2{}, AllNeighbors, 3},
+		{V2{X: 1}, AllNeighbors, 5},
+	} {
+		if n := count(tc.v, tc.typ); n != tc.expected {
+			t.Fatalf("Neighbors of %v expected %d got %d", tc.v, tc.expected, n)
+		}
+	}
+
+	allocs := testing.AllocsPerRun(10, func() { count(V2{X: 1, Y: 1}, AllNeighbors) })
+	if allocs != 0 {
+		t.Fatalf("NeighborIter expected no allocations got %v", allocs)
+	}
+}
+
+func TestGridFind(t *testing.T) {
+	gd := ParseByteGrid([]string{"..#", "#.S"})
+	isWall := func(c byte) bool { return c == '#' }
+
+	if v, ok := gd.Find(func(c byte) bool { return c == 'S' }); !ok || v != (V2{X: 2, Y: 1}) {
+		t.Fatalf("Find expected (2, 1) got %v %v", v, ok)
+	}
+	if _, ok := gd.Find(func(c byte) bool { return c == 'x' }); ok {
+		t.Fatalf("Find expected nothing for a missing cell")
+	}
+	if walls := gd.FindAll(isWall); len(walls) != 2 || walls[0] != (V2{X: 2}) || walls[1] != (V2{Y: 1}) {
+		t.Fatalf("FindAll expected [(2, 0) (0, 1)] got %v", walls)
+	}
+
+	expected := ".." + Red("#") + "\n#.S\n"
+	if s := gd.Format(V2{X: 2}); s != expected {
+		t.Fatalf("Format expected %q got %q", expected, s)
+	}
+}
@@ -0,0 +1,127 @@
+package utils
+
+import "fmt"
+
+// Transforms return a transformed copy, or change the grid in place when
+// named in the imperative. All of them work on any rectangular grid
+
+// A copy with rows and columns swapped
+func (self *Grid[T]) Transposed() Grid[T] {
+	res := NewGrid[T](self.w, self.h)
+	for it := self.Iter(); it.Next(); {
+		res.data[it.V.X*res.w+it.V.Y] = self.At(it.V)
+	}
+	return res
+}
+
+// A copy rotated a quarter turn clockwise
+func (self *Grid[T]) RotatedCW() Grid[T] {
+	res := NewGrid[T](self.w, self.h)
+	for it := self.Iter(); it.Next(); {
+		res.data[it.V.X*res.w+self.h-1-it.V.Y] = self.At(it.V)
+	}
+	return res
+}
+
+// A copy rotated a quarter turn counterclockwise
+func (self *Grid[T]) RotatedCCW() Grid[T] {
+	res := NewGrid[T](self.w, self.h)
+	for it := self.Iter(); it.Next(); {
+		res.data[(self.w-1-it.V.X)*res.w+it.V.Y] = self.At(it.V)
+	}
+	return res
+}
+
+// A copy mirrored left to right
+func (self *Grid[T]) FlippedH() Grid[T] {
+	res := self.Clone()
+	res.FlipH()
+	return res
+}
+
+// A copy mirrored top to bottom
+func (self *Grid[T]) FlippedV() Grid[T] {
+	res := self.Clone()
+	res.FlipV()
+	return res
+}
+
+func (self *Grid[T]) Transpose() {
+	*self = self.Transposed()
+}
+
+func (self *Grid[T]) RotateCW() {
+	*self = self.RotatedCW()
+}
+
+func (self *Grid[T]) RotateCCW() {
+	*self = self.RotatedCCW()
+}
+
+// Mirror left to right
+func (self *Grid[T]) FlipH() {
+	for y := 0; y < self.h; y++ {
+		Reverse(self.Row(y))
+	}
+}
+
+// Mirror top to bottom
+func (self *Grid[T]) FlipV() {
+	for a, b := 0, self.h-1; a < b; a, b = a+1, b-1 {
+		ra, rb := self.Row(a), self.Row(b)
+		for x := range ra {
+			ra[x], rb[x] = rb[x], ra[x]
+		}
+	}
+}
+
+// Slide every movable cell one cardinal direction dir as far as it goes
+// through open cells, swapping places with them. Any other cell blocks.
+// Returns whether anything moved. Panics if dir isn't a unit cardinal step
+//
+//	..O#.O  slid {X: -1} ->  O..#O.
+func (self *Grid[T]) Slide(dir V2, movable, open func(T) bool) bool {
+	if IntAbs(dir.X)+IntAbs(dir.Y) != 1 {
+		panic(fmt.Sprintf("can't slide in direction %v, it must be one cardinal step", dir))
+	}
+
+	// each line is walked against dir from the edge dir points to
+	var edge GridIter
+	switch {
+	case dir.X > 0:
+		edge = self.IterCol(self.w - 1)
+	case dir.X < 0:
+		edge = self.IterCol(0)
+	case dir.Y > 0:
+		edge = self.IterRow(self.h - 1)
+	default:
+		edge = self.IterRow(0)
+	}
+	back := V2{X: -dir.X, Y: -dir.Y}
+
+	moved := false
+	for edge.Next() {
+		// the open cell nearest the edge not yet filled
+		var free V2
+		hasFree := false
+		for v := edge.V; self.InBounds(v); v = v.Add(&back) {
+			c := self.At(v)
+			switch {
+			case open(c):
+				if !hasFree {
+					free, hasFree = v, true
+				}
+			case movable(c):
+				if hasFree {
+					self.Set(v, self.At(free))
+					self.Set(free, c)
+					free = free.Add(&back)
+					moved = true
+				}
+			default:
+				hasFree = false
+			}
+		}
+	}
+	return moved
+}
@@ -0,0 +1,85 @@
+package utils
+
+import "testing"
+
+func TestGridTransforms(t *testing.T) {
+	gd := ParseByteGrid([]string{
+		"abc",
+		"def",
+	})
+
+	for _, tc := range []struct {
+		name     string
+		res      Grid[byte]
+		expected string
+	}{
+		{"Transposed", gd.Transposed(), "ad\nbe\ncf\n"},
+		{"RotatedCW", gd.RotatedCW(), "da\neb\nfc\n"},
+		{"RotatedCCW", gd.RotatedCCW(), "cf\nbe\nad\n"},
+		{"FlippedH", gd.FlippedH(), "cba\nfed\n"},
+		{"FlippedV", gd.FlippedV(), "def\nabc\n"},
+	} {
+		if s := tc.res.String(); s != tc.expected {
+			t.Fatalf("%s expected %q got %q", tc.name, tc.expected, s)
+		}
+	}
+	if s := gd.String(); s != "abc\ndef\n" {
+		t.Fatalf("Copies changed the grid to %q", s)
+	}
+
+	cl := gd.Clone()
+	for i := 0; i < 4; i++ {
+		cl.RotateCW()
+	}
+	if s := cl.String(); s != gd.String() {
+		t.Fatalf("Four RotateCW expected %q got %q", gd.String(), s)
+	}
+	cl.RotateCCW()
+	cl.Transpose()
+	cl.FlipH()
+	if s := cl.String(); s != gd.String() {
+		t.Fatalf("RotateCCW, Transpose and FlipH expected %q got %q", gd.String(), s)
+	}
+}
+
+func TestGridSlide(t *testing.T) {
+	gd := ParseByteGrid([]string{
+		"..O#.O",
+		"O.O.#.",
+		".O....",
+	})
+	rock := func(c byte) bool { return c == 'O' }
+	open := func(c byte) bool { return c == '.' }
+
+	for _, tc := range []struct {
+		dir      V2
+		expected string
+	}{
+		{V2{X: -1}, "O..#O.\nOO..#.\nO.....\n"},
+		{V2{X: 1}, "..O#.O\n..OO#.\n.....O\n"},
+		{V2{Y: -1}, "OOO#.O\n..O.#.\n......\n"},
+		{V2{Y: 1}, "...#..\n..O.#.\nOOO..O\n"},
+	} {
+		cl := gd.Clone()
+		cl.Slide(tc.dir, rock, open)
+		if s := cl.String(); s != tc.expected {
+			t.Fatalf("Slide %v expected %q got %q", tc.dir, tc.expected, s)
+		}
+	}
+
+	cl := gd.Clone()
+	if !cl.Slide(V2{X: -1}, rock, open) || cl.Slide(V2{X: -1}, rock, open) {
+		t.Fatalf("Slide should move only the first time")
+	}
+
+	for _, dir := range []V2{{}, {X: 1, Y: 1}, {X: 2}, {Y: -3}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Slide %v expected to panic", dir)
+				}
+			}()
+			cl.Slide(dir, rock, open)
+		}()
+	}
+}
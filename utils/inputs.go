@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const (
+	INPUTS_ENV     = "AOC_INPUTS"
+	CONFIG_ENV     = "AOC_CONFIG"
+	DEFAULT_INPUTS = "~/sync/dev/aoc_inputs"
+	DEFAULT_CONFIG = "~/.config/aoc/config.json"
+	// Variant of the real puzzle input, examples are "example", "example2", ...
+	INPUT_VARIANT = "input"
+)
+
+// Contents of the config file. Paths may start with ~
+//
+//	{
+//	  "inputs": "~/aoc_inputs",
+//	  "days": {"2021/1": {"input": "~/old/inp1.txt"}}
+//	}
+type InputConfig struct {
+	// Directory holding <year>/<day>/<variant>.txt
+	Inputs string `json:"inputs"`
+	// Per-day variant paths, keyed by "<year>/<day>"
+	Days map[string]map[string]string `json:"days"`
+}
+
+// Resolves where a day's inputs live
+type InputLocator struct {
+	Root string
+	Days map[string]map[string]string
+}
+
+// Read the config file from $AOC_CONFIG or DEFAULT_CONFIG. A missing file is
+// an empty config
+func LoadInputConfig() (InputConfig, error) {
+	var cfg InputConfig
+	fname := os.Getenv(CONFIG_ENV)
+	if fname == "" {
+		fname = DEFAULT_CONFIG
+	}
+
+	buf, err := os.ReadFile(ExpandUser(fname))
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(buf, &cfg); err != nil {
+		return cfg, fmt.Errorf("%s: %w", fname, err)
+	}
+	return cfg, nil
+}
+
+// Build a locator. The inputs directory is the first set of: root (usually
+// from a CLI flag), $AOC_INPUTS, the config file and DEFAULT_INPUTS
+func NewInputLocator(root string) (InputLocator, error) {
+	cfg, err := LoadInputConfig()
+	if err != nil {
+		return InputLocator{}, err
+	}
+
+	for _, r := range []string{root, os.Getenv(INPUTS_ENV), cfg.Inputs, DEFAULT_INPUTS} {
+		if r != "" {
+			root = r
+			break
+		}
+	}
+	return InputLocator{ExpandUser(root), cfg.Days}, nil
+}
+
+// Directory holding a day's inputs
+func (self *InputLocator) Dir(year, day int) string {
+	return filepath.Join(self.Root, strconv.Itoa(year), strconv.Itoa(day))
+}
+
+// Path of a day's input variant. Per-day config wins over the default
+// <root>/<year>/<day>/<variant>.txt layout
+func (self *InputLocator) Path(year, day int, variant string) string {
+	if p, ok := self.Days[fmt.Sprintf("%d/%d", year, day)][variant]; ok {
+		return ExpandUser(p)
+	}
+	return filepath.Join(self.Dir(year, day), variant+".txt")
+}
+
+func (self *InputLocator) Read(year, day int, variant string) (string, error) {
+	buf, err := os.ReadFile(self.Path(year, day, variant))
+	return string(buf), err
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeConfig(t *testing.T, contents string) {
+	fname := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(fname, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(CONFIG_ENV, fname)
+}
+
+func TestInputLocatorPrecedence(t *testing.T) {
+	writeConfig(t, `{"inputs": "/from/config"}`)
+	t.Setenv(INPUTS_ENV, "/from/env")
+
+	cases := []struct {
+		flag string
+		env  string
+		exp  string
+	}{
+		{"/from/flag", "/from/env", "/from/flag"},
+		{"", "/from/env", "/from/env"},
+		{"", "", "/from/config"},
+	}
+
+	for _, c := range cases {
+		t.Setenv(INPUTS_ENV, c.env)
+		loc, err := NewInputLocator(c.flag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loc.Root != c.exp {
+			t.Fatalf("Root with flag %q env %q expected %s got %s", c.flag, c.env, c.exp, loc.Root)
+		}
+	}
+}
+
+func TestInputLocatorDefault(t *testing.T) {
+	t.Setenv(CONFIG_ENV, filepath.Join(t.TempDir(), "missing.json"))
+	t.Setenv(INPUTS_ENV, "")
+
+	loc, err := NewInputLocator("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := ExpandUser(DEFAULT_INPUTS); loc.Root != exp {
+		t.Fatalf("Default root expected %s got %s", exp, loc.Root)
+	}
+}
+
+func TestInputLocatorPath(t *testing.T) {
+	writeConfig(t, `{"inputs": "/aoc", "days": {"2021/1": {"input": "/old/inp1.txt"}}}`)
+	t.Setenv(INPUTS_ENV, "")
+
+	loc, err := NewInputLocator("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		year    int
+		day     int
+		variant string
+		exp     string
+	}{
+		{2023, 8, INPUT_VARIANT, "/aoc/2023/8/input.txt"},
+		{2023, 8, "example2", "/aoc/2023/8/example2.txt"},
+		{2021, 1, INPUT_VARIANT, "/old/inp1.txt"},
+		{2021, 1, "example", "/aoc/2021/1/example.txt"},
+	}
+	for _, c := range cases {
+		p := loc.Path(c.year, c.day, c.variant)
+		if p != c.exp {
+			t.Fatalf("Path(%d, %d, %s) expected %s got %s", c.year, c.day, c.variant, c.exp, p)
+		}
+	}
+}
+
+func TestInputLocatorBadConfig(t *testing.T) {
+	writeConfig(t, `{"inputs": `)
+	if _, err := NewInputLocator(""); err == nil {
+		t.Fatalf("Expected error for malformed config")
+	}
+}
@@ -0,0 +1,254 @@
+package utils
+
+import "sort"
+
+// The half-open interval [Lo, Hi), empty when Hi <= Lo
+type Interval[T numeric] struct {
+	Lo, Hi T
+}
+
+// Exact min and max, as Min and Max round through float64 and lose precision
+// above 2^53
+func lesser[T numeric](a, b T) T {
+	if b < a {
+		return b
+	}
+	return a
+}
+
+func greater[T numeric](a, b T) T {
+	if b > a {
+		return b
+	}
+	return a
+}
+
+// The interval of length values from start
+func IntervalOfLen[T numeric](start, length T) Interval[T] {
+	return Interval[T]{start, start + length}
+}
+
+func (self Interval[T]) Empty() bool {
+	return self.Hi <= self.Lo
+}
+
+// Number of values in the interval, 0 if empty
+func (self Interval[T]) Len() T {
+	if self.Empty() {
+		return 0
+	}
+	return self.Hi - self.Lo
+}
+
+func (self Interval[T]) Contains(v T) bool {
+	return self.Lo <= v && v < self.Hi
+}
+
+// Whether every value of other is in the interval. Empty intervals are in any
+func (self Interval[T]) ContainsInterval(other Interval[T]) bool {
+	return other.Empty() || (self.Lo <= other.Lo && other.Hi <= self.Hi)
+}
+
+func (self Interval[T]) Overlaps(other Interval[T]) bool {
+	return !self.Intersect(other).Empty()
+}
+
+// Values in both intervals, possibly empty
+func (self Interval[T]) Intersect(other Interval[T]) Interval[T] {
+	return Interval[T]{greater(self.Lo, other.Lo), lesser(self.Hi, other.Hi)}
+}
+
+// Split into the values below v and those from v on, either possibly empty
+func (self Interval[T]) SplitAt(v T) (Interval[T], Interval[T]) {
+	return Interval[T]{self.Lo, lesser(self.Hi, v)}, Interval[T]{greater(self.Lo, v), self.Hi}
+}
+
+// The interval moved by d
+func (self Interval[T]) Shift(d T) Interval[T] {
+	return Interval[T]{self.Lo + d, self.Hi + d}
+}
+
+// A set of values stored as sorted, disjoint and non-touching intervals. The
+// zero value is an empty set
+type IntervalSet[T numeric] struct {
+	ivs []Interval[T]
+}
+
+// The set of the values in any of ivs
+func NewIntervalSet[T numeric](ivs ...Interval[T]) IntervalSet[T] {
+	var s IntervalSet[T]
+	for _, iv := range ivs {
+		if !iv.Empty() {
+			s.ivs = append(s.ivs, iv)
+		}
+	}
+	s.normalize()
+	return s
+}
+
+// Sort the intervals and merge ones that overlap or touch
+func (self *IntervalSet[T]) normalize() {
+	sort.Slice(self.ivs, func(i, j int) bool { return self.ivs[i].Lo < self.ivs[j].Lo })
+	n := 0
+	for _, iv := range self.ivs {
+		if n > 0 && iv.Lo <= self.ivs[n-1].Hi {
+			self.ivs[n-1].Hi = greater(self.ivs[n-1].Hi, iv.Hi)
+			continue
+		}
+		self.ivs[n] = iv
+		n++
+	}
+	self.ivs = self.ivs[:n]
+}
+
+// The intervals of the set in order. Must not be modified
+func (self *IntervalSet[T]) Intervals() []Interval[T] {
+	return self.ivs
+}
+
+func (self *IntervalSet[T]) Empty() bool {
+	return len(self.ivs) == 0
+}
+
+// Number of values in the set
+func (self *IntervalSet[T]) Len() T {
+	var tot T
+	for _, iv := range self.ivs {
+		tot += iv.Len()
+	}
+	return tot
+}
+
+// The smallest value in the set, if it isn't empty
+func (self *IntervalSet[T]) Min() (T, bool) {
+	if self.Empty() {
+		return 0, false
+	}
+	return self.ivs[0].Lo, true
+}
+
+func (self *IntervalSet[T]) Contains(v T) bool {
+	i := sort.Search(len(self.ivs), func(i int) bool { return self.ivs[i].Hi > v })
+	return i < len(self.ivs) && self.ivs[i].Contains(v)
+}
+
+// Add the values of iv to the set
+func (self *IntervalSet[T]) Add(iv Interval[T]) {
+	if !iv.Empty() {
+		// copies of the set may share ivs, so never append in place
+		self.ivs = append(self.ivs[:len(self.ivs):len(self.ivs)], iv)
+		self.normalize()
+	}
+}
+
+// Values in either set
+func (self *IntervalSet[T]) Union(other IntervalSet[T]) IntervalSet[T] {
+	ivs := append(append([]Interval[T](nil), self.ivs...), other.ivs...)
+	return NewIntervalSet(ivs...)
+}
+
+// Values in both sets
+func (self *IntervalSet[T]) Intersect(other IntervalSet[T]) IntervalSet[T] {
+	var res IntervalSet[T]
+	for i, j := 0, 0; i < len(self.ivs) && j < len(other.ivs); {
+		a, b := self.ivs[i], other.ivs[j]
+		if iv := a.Intersect(b); !iv.Empty() {
+			res.ivs = append(res.ivs, iv)
+		}
+		// drop whichever ends first, it can't overlap anything further
+		if a.Hi < b.Hi {
+			i++
+		} else {
+			j++
+		}
+	}
+	return res
+}
+
+// Values in the set but not in other
+func (self *IntervalSet[T]) Difference(other IntervalSet[T]) IntervalSet[T] {
+	var res IntervalSet[T]
+	j := 0
+	for _, iv := range self.ivs {
+		// skip what ends before iv, then cut out everything overlapping it
+		for j < len(other.ivs) && other.ivs[j].Hi <= iv.Lo {
+			j++
+		}
+		for k := j; k < len(other.ivs) && other.ivs[k].Lo < iv.Hi; k++ {
+			below, above := iv.SplitAt(other.ivs[k].Lo)
+			if !below.Empty() {
+				res.ivs = append(res.ivs, below)
+			}
+			_, iv = above.SplitAt(other.ivs[k].Hi)
+		}
+		if !iv.Empty() {
+			res.ivs = append(res.ivs, iv)
+		}
+	}
+	return res
+}
+
+// Split into the values below v and those from v on
+func (self *IntervalSet[T]) SplitAt(v T) (IntervalSet[T], IntervalSet[T]) {
+	var below, above IntervalSet[T]
+	for _, iv := range self.ivs {
+		b, a := iv.SplitAt(v)
+		if !b.Empty() {
+			below.ivs = append(below.ivs, b)
+		}
+		if !a.Empty() {
+			above.ivs = append(above.ivs, a)
+		}
+	}
+	return below, above
+}
+
+// The set with every value moved by d
+func (self *IntervalSet[T]) Shift(d T) IntervalSet[T] {
+	res := IntervalSet[T]{make([]Interval[T], len(self.ivs))}
+	for i, iv := range self.ivs {
+		res.ivs[i] = iv.Shift(d)
+	}
+	return res
+}
+
+type offsetPiece[T numeric] struct {
+	src    Interval[T]
+	offset T
+}
+
+// A piecewise function moving the values of each of its intervals by an
+// offset. Values in no interval map to themselves, and where intervals overlap
+// the first one added applies. The zero value is the identity
+type OffsetMap[T numeric] struct {
+	pieces []offsetPiece[T]
+}
+
+// Move the values of src by offset
+func (self *OffsetMap[T]) Add(src Interval[T], offset T) {
+	self.pieces = append(self.pieces, offsetPiece[T]{src, offset})
+}
+
+// Where v maps to
+func (self *OffsetMap[T]) Map(v T) T {
+	for _, p := range self.pieces {
+		if p.src.Contains(v) {
+			return v + p.offset
+		}
+	}
+	return v
+}
+
+// Where all the values of s map to
+func (self *OffsetMap[T]) MapSet(s IntervalSet[T]) IntervalSet[T] {
+	var res IntervalSet[T]
+	for _, p := range self.pieces {
+		src := NewIntervalSet(p.src)
+		hit := s.Intersect(src)
+		res.ivs = append(res.ivs, hit.Shift(p.offset).ivs...)
+		s = s.Difference(src)
+	}
+	res.ivs = append(res.ivs, s.ivs...)
+	res.normalize()
+	return res
+}
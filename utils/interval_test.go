@@ -0,0 +1,124 @@
+package utils
+
+import "testing"
+
+func TestInterval(t *testing.T) {
+	a := Interval[int]{2, 8}
+	if a.Len() != 6 || !a.Contains(2) || a.Contains(8) {
+		t.Fatalf("Interval %v wrong length or bounds", a)
+	}
+	if e := (Interval[int]{5, 3}); !e.Empty() || e.Len() != 0 {
+		t.Fatalf("Interval %v expected empty", e)
+	}
+	if iv := a.Intersect(Interval[int]{6, 10}); iv != (Interval[int]{6, 8}) {
+		t.Fatalf("Intersect expected [6, 8) got %v", iv)
+	}
+	if a.Overlaps(Interval[int]{8, 10}) || !a.Overlaps(Interval[int]{7, 10}) {
+		t.Fatalf("Overlaps wrong at the end of %v", a)
+	}
+	if !a.ContainsInterval(Interval[int]{3, 8}) || a.ContainsInterval(Interval[int]{3, 9}) {
+		t.Fatalf("ContainsInterval wrong for %v", a)
+	}
+
+	below, above := a.SplitAt(5)
+	if below != (Interval[int]{2, 5}) || above != (Interval[int]{5, 8}) {
+		t.Fatalf("SplitAt(5) expected [2, 5) [5, 8) got %v %v", below, above)
+	}
+	if below, _ := a.SplitAt(0); !below.Empty() {
+		t.Fatalf("SplitAt(0) expected empty below got %v", below)
+	}
+}
+
+func TestIntervalSet(t *testing.T) {
+	s := NewIntervalSet(Interval[int]{5, 10}, Interval[int]{0, 2}, Interval[int]{8, 12}, Interval[int]{2, 3}, Interval[int]{20, 20})
+	exp := []Interval[int]{{0, 3}, {5, 12}}
+	if !SliceEq(s.Intervals(), exp) {
+		t.Fatalf("NewIntervalSet expected %v got %v", exp, s.Intervals())
+	}
+	if s.Len() != 10 || !s.Contains(11) || s.Contains(3) || s.Contains(12) {
+		t.Fatalf("IntervalSet %v wrong length or contents", s.Intervals())
+	}
+
+	o := NewIntervalSet(Interval[int]{1, 6}, Interval[int]{7, 8}, Interval[int]{11, 15})
+	for _, tc := range []struct {
+		name     string
+		res      IntervalSet[int]
+		expected []Interval[int]
+	}{
+		{"Union", s.Union(o), []Interval[int]{{0, 15}}},
+		{"Intersect", s.Intersect(o), []Interval[int]{{1, 3}, {5, 6}, {7, 8}, {11, 12}}},
+		{"Difference", s.Difference(o), []Interval[int]{{0, 1}, {6, 7}, {8, 11}}},
+		{"Difference other way", o.Difference(s), []Interval[int]{{3, 5}, {12, 15}}},
+	} {
+		if !SliceEq(tc.res.Intervals(), tc.expected) {
+			t.Fatalf("%s expected %v got %v", tc.name, tc.expected, tc.res.Intervals())
+		}
+	}
+
+	below, above := s.SplitAt(6)
+	if !SliceEq(below.Intervals(), []Interval[int]{{0, 3}, {5, 6}}) || !SliceEq(above.Intervals(), []Interval[int]{{6, 12}}) {
+		t.Fatalf("SplitAt(6) got %v %v", below.Intervals(), above.Intervals())
+	}
+
+	s.Add(Interval[int]{3, 5})
+	if !SliceEq(s.Intervals(), []Interval[int]{{0, 12}}) {
+		t.Fatalf("Add expected [0, 12) got %v", s.Intervals())
+	}
+
+	// merging leaves spare capacity, which a copy mustn't write into
+	orig := NewIntervalSet(Interval[int]{0, 2}, Interval[int]{1, 3}, Interval[int]{10, 12})
+	cp := orig
+	cp.Add(Interval[int]{5, 6})
+	if !SliceEq(orig.Intervals(), []Interval[int]{{0, 3}, {10, 12}}) {
+		t.Fatalf("Add to a copy changed the original to %v", orig.Intervals())
+	}
+	if !SliceEq(cp.Intervals(), []Interval[int]{{0, 3}, {5, 6}, {10, 12}}) {
+		t.Fatalf("Add to a copy expected [0, 3) [5, 6) [10, 12) got %v", cp.Intervals())
+	}
+}
+
+func TestOffsetMap(t *testing.T) {
+	// the seed-to-soil map of 2023/5
+	var m OffsetMap[int]
+	m.Add(IntervalOfLen(98, 2), 50-98)
+	m.Add(IntervalOfLen(50, 48), 52-50)
+
+	for v, exp := range map[int]int{79: 81, 14: 14, 55: 57, 13: 13, 98: 50, 100: 100} {
+		if res := m.Map(v); res != exp {
+			t.Fatalf("Map(%d) expected %d got %d", v, exp, res)
+		}
+	}
+
+	res := m.MapSet(NewIntervalSet(IntervalOfLen(79, 14), IntervalOfLen(95, 10)))
+	exp := []Interval[int]{{50, 52}, {81, 95}, {97, 105}}
+	if !SliceEq(res.Intervals(), exp) {
+		t.Fatalf("MapSet expected %v got %v", exp, res.Intervals())
+	}
+	if res.Len() != 24 {
+		t.Fatalf("MapSet expected 24 values got %d", res.Len())
+	}
+}
+
+func TestIntervalLarge(t *testing.T) {
+	// past 2^53 float64 can't tell neighbouring values apart
+	big := Interval[int]{1<<60 + 1, 1<<60 + 3}
+	if iv := big.Intersect(big); iv != big {
+		t.Fatalf("Intersect expected %v got %v", big, iv)
+	}
+	below, above := big.SplitAt(1<<60 + 2)
+	if below != (Interval[int]{1<<60 + 1, 1<<60 + 2}) || above != (Interval[int]{1<<60 + 2, 1<<60 + 3}) {
+		t.Fatalf("SplitAt expected halves of %v got %v %v", big, below, above)
+	}
+
+	var m OffsetMap[int]
+	m.Add(big, 5)
+	res := m.MapSet(NewIntervalSet(big))
+	if exp := []Interval[int]{big.Shift(5)}; !SliceEq(res.Intervals(), exp) {
+		t.Fatalf("MapSet expected %v got %v", exp, res.Intervals())
+	}
+
+	u := Interval[uint64]{1<<63 + 1, 1<<63 + 2}
+	if iv := u.Intersect(u); iv != u {
+		t.Fatalf("Intersect expected %v got %v", u, iv)
+	}
+}
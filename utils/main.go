@@ -56,33 +56,39 @@ func ExpandUser(filename string) string {
 
 // Read all non-empty lines from file
 func ReadLines(filename string) []string {
-	lines := ReadAllLines(filename)
-	var ret []string
-	for _, ln := range lines {
-		if ln != "" {
-			ret = append(ret, ln)
-		}
-	}
-	return ret
+	return NonEmptyLines(ReadFile(filename))
 }
 
 // Read all lines from file, including empty
 func ReadAllLines(filename string) []string {
+	return ParseLines(ReadFile(filename))
+}
+
+// Read the whole file
+func ReadFile(filename string) string {
 	filename = ExpandUser(filename)
 	buf, err := os.ReadFile(filename)
 	if err != nil {
 		panic(err)
 	}
-	return ParseLines(string(buf))
+	return string(buf)
 }
 
+// Split buffer into lines, including empty
 func ParseLines(buf string) []string {
+	// a trailing newline doesn't start another line
+	return strings.Split(strings.TrimSuffix(buf, "\n"), "\n")
+}
+
+// Split buffer into non-empty lines
+func NonEmptyLines(buf string) []string {
 	var ret []string
-	for _, v := range strings.Split(buf, "\n") {
-		ret = append(ret, v)
+	for _, ln := range strings.Split(buf, "\n") {
+		if ln != "" {
+			ret = append(ret, ln)
+		}
 	}
-	// should be a last empty string in there, remove it
-	return ret[:len(ret)-1]
+	return ret
 }
 
 // Slice stuff
@@ -105,7 +111,7 @@ func Transpose(m []string) []string {
 }
 
 func ReverseAll(m []string) []string {
-	tmp := make([]string, len(m[0]))
+	tmp := make([]string, len(m))
 	for i, ln := range m {
 		tmp[i] = string(Reversed([]byte(ln)))
 	}
@@ -142,6 +148,13 @@ func Reversed[T any](in []T) []T {
 	return out
 }
 
+// Reverse in in place
+func Reverse[T any](in []T) {
+	for i, j := 0, len(in)-1; i < j; i, j = i+1, j-1 {
+		in[i], in[j] = in[j], in[i]
+	}
+}
+
 func Insert[T any](slice []T, i int, v T) []T {
 	if i >= len(slice) {
 		return append(slice, v)
@@ -368,8 +381,29 @@ func (s *Set[T]) Equals(other *Set[T]) bool {
 		s.ContainsAll(other.Values())
 }
 
+// Sets used throughout 2021
+
+type IntSet = Set[int]
+type StrSet = Set[string]
+type PointSet = Set[Point]
+
+func NewIntSet(vals ...int) IntSet {
+	return NewSet(vals)
+}
+
+func NewStrSet(vals ...string) StrSet {
+	return NewSet(vals)
+}
+
+func NewPointSet(vals ...Point) PointSet {
+	return NewSet(vals)
+}
+
 // Vector
 
+// An x, y pair that can be indexed by axis
+type Point [2]int
+
 type V2 struct {
 	X int
 	Y int
@@ -425,45 +459,3 @@ func GridAt[T any](gd [][]T, c V2) T {
 func InBounds[T any](gd [][]T, c V2) bool {
 	return Between(c.Y, 0, len(gd)) && Between(c.X, 0, len(gd[0]))
 }
-
-type Grid[T any] struct {
-	Cells [][]T
-}
-
-func (self *Grid[T]) H() int {
-	return len(self.Cells)
-}
-
-func (self *Grid[T]) W() int {
-	return len(self.Cells[0])
-}
-
-func (self *Grid[T]) At(v V2) T {
-	return self.Cells[v.Y][v.X]
-}
-
-func (self *Grid[T]) InBounds(v V2) bool {
-	return Between(v.Y, 0, self.H()) && Between(v.X, 0, self.W())
-}
-
-func (self *Grid[T]) Neighbors(v V2, diag bool) []V2 {
-	nb := []V2{
-		{X: v.X + 1, Y: v.Y},
-		{X: v.X - 1, Y: v.Y},
-		{X: v.X, Y: v.Y + 1},
-		{X: v.X, Y: v.Y - 1},
-	}
-
-	if diag {
-		nb = append(nb, []V2{
-			{X: v.X + 1, Y: v.Y + 1},
-			{X: v.X + 1, Y: v.Y - 1},
-			{X: v.X - 1, Y: v.Y + 1},
-			{X: v.X - 1, Y: v.Y - 1},
-		}...)
-	}
-
-	return Filter(nb, func(v V2, i int) bool {
-		return self.InBounds(v)
-	})
-}
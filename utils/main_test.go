@@ -65,6 +65,16 @@ func TestTranspose(t *testing.T) {
 	}
 }
 
+func TestReverseAll(t *testing.T) {
+	in := []string{"ab", "cd", "ef"}
+	exp := []string{"ba", "dc", "fe"}
+
+	res := ReverseAll(in)
+	if !SliceEq(exp, res) {
+		t.Fatalf("ReverseAll %v expected %v got %v", in, exp, res)
+	}
+}
+
 func TestV2Sub(t *testing.T) {
 	a := V2{1, 1}
 	b := V2{0, 1}
@@ -82,3 +92,33 @@ func TestV2Unit(t *testing.T) {
 		t.Fatalf("Wrong unit for %v expected %v got %v", a, exp, a.Unit())
 	}
 }
+
+func TestNewIntSet(t *testing.T) {
+	s := NewIntSet(1, 2, 2, 3)
+	exp := NewSet([]int{1, 2, 3})
+	if !s.Equals(&exp) {
+		t.Fatalf("NewIntSet expected %v got %v", exp, s)
+	}
+
+	empty := NewIntSet()
+	empty.Add(4)
+	if !empty.Contains(4) || empty.Size() != 1 {
+		t.Fatalf("Empty NewIntSet add expected {4} got %v", empty)
+	}
+}
+
+func TestStrSetCopy(t *testing.T) {
+	a := NewStrSet("a", "b")
+	b := a.Copy()
+	b.Remove("a")
+	if !a.Contains("a") || b.Contains("a") {
+		t.Fatalf("Copy shares storage: a %v b %v", a, b)
+	}
+}
+
+func TestPointSet(t *testing.T) {
+	s := NewPointSet(Point{1, 2}, Point{3, 4})
+	if !s.Contains(Point{1, 2}) || s.Contains(Point{2, 1}) {
+		t.Fatalf("PointSet contains wrong points %v", s)
+	}
+}
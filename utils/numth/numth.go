@@ -0,0 +1,124 @@
+// Package numth has the number theory puzzles keep needing: GCD and LCM,
+// modular arithmetic and the Chinese Remainder Theorem
+package numth
+
+import "math/bits"
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
+
+// Greatest common divisor of ns, always non-negative. GCD() is 0
+func GCD(ns ...int) int {
+	g := 0
+	for _, n := range ns {
+		a, b := g, abs(n)
+		for b != 0 {
+			a, b = b, a%b
+		}
+		g = a
+	}
+	return g
+}
+
+// Least common multiple of ns, always non-negative. 0 if any of them is, and
+// LCM() is 1
+func LCM(ns ...int) int {
+	l := 1
+	for _, n := range ns {
+		if n == 0 {
+			return 0
+		}
+		l = l / GCD(l, n) * abs(n)
+	}
+	return l
+}
+
+// Extended Euclid: g = GCD(a, b) along with x and y such that a*x + b*y = g
+func ExtGCD(a, b int) (g, x, y int) {
+	oldR, r := a, b
+	oldX, x := 1, 0
+	oldY, y := 0, 1
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// a mod m in [0, m) for m > 0, unlike % which keeps the sign of a
+func Mod(a, m int) int {
+	a %= m
+	if a < 0 {
+		a += m
+	}
+	return a
+}
+
+// a*b mod m without overflowing, for m > 0
+func MulMod(a, b, m int) int {
+	hi, lo := bits.Mul64(uint64(Mod(a, m)), uint64(Mod(b, m)))
+	return int(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// base**exp mod m for exp >= 0 and m > 0
+func PowMod(base, exp, m int) int {
+	res := 1 % m
+	base = Mod(base, m)
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			res = MulMod(res, base, m)
+		}
+		base = MulMod(base, base, m)
+	}
+	return res
+}
+
+// x in [0, m) with a*x = 1 mod m, if a and m are coprime
+func ModInverse(a, m int) (int, bool) {
+	g, x, _ := ExtGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, false
+	}
+	return Mod(x, m), true
+}
+
+// The numbers x with x = Rem mod Mod. Rem is in [0, Mod)
+type Congruence struct {
+	Rem, Mod int
+}
+
+// The smallest x >= min in the congruence
+func (self Congruence) AtLeast(min int) int {
+	return min + Mod(self.Rem-min, self.Mod)
+}
+
+// Combine congruences into the one holding the numbers in all of them. The
+// moduli don't need to be coprime: ok is false when no number is in all of
+// them. Negative remainders are allowed, and CRT() is every number
+func CRT(cs ...Congruence) (res Congruence, ok bool) {
+	res = Congruence{0, 1}
+	for _, c := range cs {
+		// res.Rem + res.Mod*t = c.Rem mod c.Mod, so
+		// res.Mod*t = c.Rem - res.Rem mod c.Mod, solvable when g divides it
+		g, p, _ := ExtGCD(res.Mod, c.Mod)
+		diff := c.Rem - res.Rem
+		if diff%g != 0 {
+			return Congruence{}, false
+		}
+
+		m := c.Mod / g
+		t := MulMod(diff/g, p, m)
+		lcm := res.Mod * m
+		res = Congruence{Mod(res.Rem+MulMod(res.Mod, t, lcm), lcm), lcm}
+	}
+	return res, true
+}
@@ -0,0 +1,76 @@
+package numth
+
+import "testing"
+
+func TestGCDLCM(t *testing.T) {
+	if g := GCD(12, -18, 30); g != 6 {
+		t.Fatalf("GCD expected 6 got %d", g)
+	}
+	if g := GCD(); g != 0 {
+		t.Fatalf("GCD() expected 0 got %d", g)
+	}
+	if l := LCM(4, 6, 10); l != 60 {
+		t.Fatalf("LCM expected 60 got %d", l)
+	}
+	if l := LCM(3, 0); l != 0 {
+		t.Fatalf("LCM with 0 expected 0 got %d", l)
+	}
+}
+
+func TestExtGCD(t *testing.T) {
+	for _, tc := range [][2]int{{240, 46}, {-240, 46}, {17, 5}, {0, 7}} {
+		a, b := tc[0], tc[1]
+		g, x, y := ExtGCD(a, b)
+		if g != GCD(a, b) || a*x+b*y != g {
+			t.Fatalf("ExtGCD(%d, %d) expected %d got %d, %d*%d + %d*%d != %d", a, b, GCD(a, b), g, a, x, b, y, g)
+		}
+	}
+}
+
+func TestModular(t *testing.T) {
+	if m := Mod(-7, 5); m != 3 {
+		t.Fatalf("Mod(-7, 5) expected 3 got %d", m)
+	}
+	if p := PowMod(3, 200, 1_000_000_007); p != 136_318_165 {
+		t.Fatalf("PowMod(3, 200) expected 136318165 got %d", p)
+	}
+	// big enough that a*b overflows
+	if m := MulMod(1<<62, 6, 1<<61+1); m != Mod(-12, 1<<61+1) {
+		t.Fatalf("MulMod overflowed, got %d", m)
+	}
+
+	if inv, ok := ModInverse(3, 11); !ok || inv != 4 {
+		t.Fatalf("ModInverse(3, 11) expected 4 got %d %v", inv, ok)
+	}
+	if _, ok := ModInverse(4, 8); ok {
+		t.Fatalf("ModInverse(4, 8) should not exist")
+	}
+}
+
+func TestCRT(t *testing.T) {
+	for _, tc := range []struct {
+		cs       []Congruence
+		expected Congruence
+		ok       bool
+	}{
+		{[]Congruence{{2, 3}, {3, 5}, {2, 7}}, Congruence{23, 105}, true},
+		// moduli with common factors
+		{[]Congruence{{2, 4}, {4, 6}}, Congruence{10, 12}, true},
+		{[]Congruence{{1, 4}, {2, 6}}, Congruence{}, false},
+		{[]Congruence{{-1, 5}, {0, 3}}, Congruence{9, 15}, true},
+		{nil, Congruence{0, 1}, true},
+	} {
+		res, ok := CRT(tc.cs...)
+		if ok != tc.ok || res != tc.expected {
+			t.Fatalf("CRT %v expected %v %v got %v %v", tc.cs, tc.expected, tc.ok, res, ok)
+		}
+	}
+
+	c := Congruence{2, 5}
+	if x := c.AtLeast(13); x != 17 {
+		t.Fatalf("AtLeast(13) expected 17 got %d", x)
+	}
+	if x := c.AtLeast(12); x != 12 {
+		t.Fatalf("AtLeast(12) expected 12 got %d", x)
+	}
+}
@@ -0,0 +1,68 @@
+package utils
+
+type pqItem[T any] struct {
+	val      T
+	priority int
+}
+
+// A binary min-heap of values ordered by an int priority. The zero value is
+// an empty queue
+type PriorityQueue[T any] struct {
+	items []pqItem[T]
+}
+
+func (self *PriorityQueue[T]) Len() int {
+	return len(self.items)
+}
+
+func (self *PriorityQueue[T]) Push(val T, priority int) {
+	self.items = append(self.items, pqItem[T]{val, priority})
+	self.up(len(self.items) - 1)
+}
+
+// Remove and return the value with the lowest priority, and its priority.
+// Panics when empty
+func (self *PriorityQueue[T]) Pop() (T, int) {
+	top := self.items[0]
+	n := len(self.items) - 1
+	self.items[0] = self.items[n]
+	self.items[n] = pqItem[T]{}
+	self.items = self.items[:n]
+	if n > 0 {
+		self.down(0)
+	}
+	return top.val, top.priority
+}
+
+// The value with the lowest priority and its priority, without removing it
+func (self *PriorityQueue[T]) Peek() (T, int) {
+	return self.items[0].val, self.items[0].priority
+}
+
+func (self *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if self.items[parent].priority <= self.items[i].priority {
+			return
+		}
+		self.items[parent], self.items[i] = self.items[i], self.items[parent]
+		i = parent
+	}
+}
+
+func (self *PriorityQueue[T]) down(i int) {
+	n := len(self.items)
+	for {
+		least := i
+		for _, c := range [2]int{2*i + 1, 2*i + 2} {
+			if c < n && self.items[c].priority < self.items[least].priority {
+				least = c
+			}
+		}
+		if least == i {
+			return
+		}
+		self.items[least], self.items[i] = self.items[i], self.items[least]
+		i = least
+	}
+}
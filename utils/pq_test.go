@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestPriorityQueue(t *testing.T) {
+	var pq PriorityQueue[string]
+	pris := []int{5, 1, 9, 3, 3, 7, 0}
+	for i, p := range pris {
+		pq.Push(string(rune('a'+i)), p)
+	}
+	if v, p := pq.Peek(); v != "g" || p != 0 {
+		t.Fatalf("Peek expected g 0 got %s %d", v, p)
+	}
+
+	var got []int
+	for pq.Len() > 0 {
+		_, p := pq.Pop()
+		got = append(got, p)
+	}
+	if !SliceEq(got, []int{0, 1, 3, 3, 5, 7, 9}) {
+		t.Fatalf("Pop order expected sorted priorities got %v", got)
+	}
+}
@@ -0,0 +1,139 @@
+package pulse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var kinds = map[string]func() Module{
+	"%": func() Module { return &FlipFlop{} },
+	"&": func() Module { return &Conjunction{} },
+}
+
+// Make modules written with prefix in the puzzle input with newModule
+func RegisterKind(prefix string, newModule func() Module) {
+	kinds[prefix] = newModule
+}
+
+var lineRe = regexp.MustCompile(`^([^a-z]*)([a-z]+) -> (.*)$`)
+
+// Parse a circuit from lines like "%a -> b, c". The prefix picks the kind of
+// module, see RegisterKind, and the broadcaster has none
+func Parse(lines []string) *Circuit {
+	c := NewCircuit()
+	for _, ln := range lines {
+		m := lineRe.FindStringSubmatch(ln)
+		if m == nil {
+			panic(fmt.Sprintf("bad module %q", ln))
+		}
+		outputs := strings.Split(m[3], ", ")
+
+		if m[2] == BROADCASTER && m[1] == "" {
+			c.Add(m[2], &Broadcaster{}, outputs...)
+			continue
+		}
+		newModule, ok := kinds[m[1]]
+		if !ok {
+			panic(fmt.Sprintf("unknown module kind %q in %q", m[1], ln))
+		}
+		c.Add(m[2], newModule(), outputs...)
+	}
+	return c
+}
+
+// Sends on every pulse it gets
+type Broadcaster struct{}
+
+func (*Broadcaster) Kind() string {
+	return ""
+}
+
+func (*Broadcaster) Receive(_ string, l Level) (Level, bool) {
+	return l, true
+}
+
+func (*Broadcaster) AppendState(b []byte) []byte {
+	return b
+}
+
+func (*Broadcaster) Reset() {}
+
+// Ignores high pulses and flips on a low one, sending high when turned on and
+// low when turned off
+type FlipFlop struct {
+	On bool
+}
+
+func (*FlipFlop) Kind() string {
+	return "%"
+}
+
+func (self *FlipFlop) Receive(_ string, l Level) (Level, bool) {
+	if l == High {
+		return Low, false
+	}
+	self.On = !self.On
+	if self.On {
+		return High, true
+	}
+	return Low, true
+}
+
+func (self *FlipFlop) AppendState(b []byte) []byte {
+	if self.On {
+		return append(b, '1')
+	}
+	return append(b, '0')
+}
+
+func (self *FlipFlop) Reset() {
+	self.On = false
+}
+
+// Remembers the last pulse from each input, sending low when they were all
+// high and high otherwise
+type Conjunction struct {
+	inputs []string
+	last   map[string]Level
+}
+
+func (*Conjunction) Kind() string {
+	return "&"
+}
+
+func (self *Conjunction) AddInput(name string) {
+	if self.last == nil {
+		self.last = make(map[string]Level)
+	}
+	self.inputs = append(self.inputs, name)
+	self.last[name] = Low
+}
+
+// The modules sending to the conjunction
+func (self *Conjunction) Inputs() []string {
+	return self.inputs
+}
+
+func (self *Conjunction) Receive(src string, l Level) (Level, bool) {
+	self.last[src] = l
+	for _, in := range self.inputs {
+		if self.last[in] == Low {
+			return High, true
+		}
+	}
+	return Low, true
+}
+
+func (self *Conjunction) AppendState(b []byte) []byte {
+	for _, in := range self.inputs {
+		b = append(b, byte('0'+self.last[in]))
+	}
+	return b
+}
+
+func (self *Conjunction) Reset() {
+	for k := range self.last {
+		self.last[k] = Low
+	}
+}
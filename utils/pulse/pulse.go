@@ -0,0 +1,274 @@
+// Package pulse simulates circuits of modules passing low and high pulses, as
+// in 2023/20. Pulses are delivered one at a time in the order they're sent,
+// and modules are pluggable: anything implementing Module can be wired in
+package pulse
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"sort"
+	"strings"
+)
+
+type Level int
+
+const (
+	Low Level = iota
+	High
+)
+
+func (self Level) String() string {
+	if self == High {
+		return "high"
+	}
+	return "low"
+}
+
+func (self Level) MarshalText() ([]byte, error) {
+	return []byte(self.String()), nil
+}
+
+func (self *Level) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "low":
+		*self = Low
+	case "high":
+		*self = High
+	default:
+		return fmt.Errorf("unknown pulse level %q", text)
+	}
+	return nil
+}
+
+type Pulse struct {
+	Src   string `json:"src"`
+	Dest  string `json:"dest"`
+	Level Level  `json:"level"`
+}
+
+// A node of a circuit
+type Module interface {
+	// Prefix of the module in the puzzle input, like % or &
+	Kind() string
+	// Handle a pulse from src. Returns the level sent to every output, or
+	// false to send nothing
+	Receive(src string, l Level) (Level, bool)
+	// Append the module's state to b, for comparing whole circuits
+	AppendState(b []byte) []byte
+	// Go back to the state before the first press
+	Reset()
+}
+
+// A module that needs to know what sends to it, like a conjunction
+type InputTracker interface {
+	AddInput(name string)
+}
+
+// Name of the module the button sends its pulse to
+const BROADCASTER = "broadcaster"
+
+// Pulse counts of a button press
+type Stats struct {
+	Press     int
+	Low, High int
+	// Pulses sent by each module by level, the button not included
+	Sent map[string][2]int
+}
+
+// A circuit of named modules. Outputs don't have to be modules: pulses to
+// names without one are counted and dropped
+type Circuit struct {
+	names   []string
+	modules map[string]Module
+	outputs map[string][]string
+	inputs  map[string][]string
+	presses int
+	trace   *json.Encoder
+}
+
+func NewCircuit() *Circuit {
+	return &Circuit{
+		modules: make(map[string]Module),
+		outputs: make(map[string][]string),
+		inputs:  make(map[string][]string),
+	}
+}
+
+// Add module m called name sending to outputs
+func (self *Circuit) Add(name string, m Module, outputs ...string) {
+	if _, ok := self.modules[name]; ok {
+		panic(fmt.Sprintf("module %s added twice", name))
+	}
+	self.names = append(self.names, name)
+	self.modules[name] = m
+	self.outputs[name] = outputs
+
+	if t, ok := m.(InputTracker); ok {
+		for _, in := range self.inputs[name] {
+			t.AddInput(in)
+		}
+	}
+	for _, out := range outputs {
+		self.inputs[out] = append(self.inputs[out], name)
+		if t, ok := self.modules[out].(InputTracker); ok {
+			t.AddInput(name)
+		}
+	}
+}
+
+// Names of the modules in the order they were added
+func (self *Circuit) Names() []string {
+	return self.names
+}
+
+func (self *Circuit) Module(name string) (Module, bool) {
+	m, ok := self.modules[name]
+	return m, ok
+}
+
+// Names of the modules sending to name, which needn't be a module itself
+func (self *Circuit) Inputs(name string) []string {
+	return self.inputs[name]
+}
+
+func (self *Circuit) Outputs(name string) []string {
+	return self.outputs[name]
+}
+
+// Presses so far
+func (self *Circuit) Presses() int {
+	return self.presses
+}
+
+// Write every pulse delivered from now on to w as a line of JSON
+func (self *Circuit) Trace(w io.Writer) {
+	if w == nil {
+		self.trace = nil
+		return
+	}
+	self.trace = json.NewEncoder(w)
+}
+
+// A pulse delivered during a press, as traced
+type Event struct {
+	Press int `json:"press"`
+	Pulse
+}
+
+// Read back the events Trace wrote
+func ReadTrace(r io.Reader) ([]Event, error) {
+	var events []Event
+	dec := json.NewDecoder(r)
+	for {
+		var e Event
+		if err := dec.Decode(&e); err == io.EOF {
+			return events, nil
+		} else if err != nil {
+			return events, err
+		}
+		events = append(events, e)
+	}
+}
+
+// Press the button, sending a low pulse to the broadcaster, and run until no
+// pulses are left
+func (self *Circuit) Press() Stats {
+	self.presses++
+	stats := Stats{Press: self.presses, Sent: make(map[string][2]int)}
+	queue := []Pulse{{"button", BROADCASTER, Low}}
+
+	for head := 0; head < len(queue); head++ {
+		p := queue[head]
+		if p.Level == High {
+			stats.High++
+		} else {
+			stats.Low++
+		}
+		if p.Src != "button" {
+			sent := stats.Sent[p.Src]
+			sent[p.Level]++
+			stats.Sent[p.Src] = sent
+		}
+		if self.trace != nil {
+			if err := self.trace.Encode(Event{self.presses, p}); err != nil {
+				panic(err)
+			}
+		}
+
+		m, ok := self.modules[p.Dest]
+		if !ok {
+			continue
+		}
+		l, send := m.Receive(p.Src, p.Level)
+		if !send {
+			continue
+		}
+		for _, out := range self.outputs[p.Dest] {
+			queue = append(queue, Pulse{p.Dest, out, l})
+		}
+	}
+	return stats
+}
+
+// Put every module back in its starting state
+func (self *Circuit) Reset() {
+	for _, name := range self.names {
+		self.modules[name].Reset()
+	}
+	self.presses = 0
+}
+
+// The state of every module, equal for circuits in the same state
+func (self *Circuit) State() string {
+	var b []byte
+	for _, name := range self.names {
+		b = self.modules[name].AppendState(b)
+		b = append(b, ';')
+	}
+	return string(b)
+}
+
+// A hash of State, for cheaply spotting repeated states
+func (self *Circuit) Hash() uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(self.State()))
+	return h.Sum64()
+}
+
+// Write the circuit as a Graphviz digraph. Flip-flops are boxes, conjunctions
+// diamonds and names without a module double circles
+func (self *Circuit) WriteDOT(w io.Writer) error {
+	var bld strings.Builder
+	bld.WriteString("digraph circuit {\n")
+
+	shapes := map[string]string{"%": "box", "&": "diamond"}
+	var sinks []string
+	for _, name := range self.names {
+		shape, ok := shapes[self.modules[name].Kind()]
+		if !ok {
+			shape = "ellipse"
+		}
+		fmt.Fprintf(&bld, "  %q [shape=%s];\n", name, shape)
+	}
+	for name := range self.inputs {
+		if _, ok := self.modules[name]; !ok {
+			sinks = append(sinks, name)
+		}
+	}
+	sort.Strings(sinks)
+	for _, name := range sinks {
+		fmt.Fprintf(&bld, "  %q [shape=doublecircle];\n", name)
+	}
+
+	for _, name := range self.names {
+		for _, out := range self.outputs[name] {
+			fmt.Fprintf(&bld, "  %q -> %q;\n", name, out)
+		}
+	}
+	bld.WriteString("}\n")
+
+	_, err := io.WriteString(w, bld.String())
+	return err
+}
@@ -0,0 +1,156 @@
+package pulse
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+var example = []string{
+	"broadcaster -> a, b, c",
+	"%a -> b",
+	"%b -> c",
+	"%c -> inv",
+	"&inv -> a",
+}
+
+var example2 = []string{
+	"broadcaster -> a",
+	"%a -> inv, con",
+	"&inv -> b",
+	"%b -> con",
+	"&con -> output",
+}
+
+func TestPress(t *testing.T) {
+	c := Parse(example)
+	start := c.Hash()
+	s := c.Press()
+	if s.Low != 8 || s.High != 4 {
+		t.Fatalf("Press expected 8 low 4 high got %d low %d high", s.Low, s.High)
+	}
+	if sent := s.Sent["inv"]; sent != [2]int{1, 1} {
+		t.Fatalf("inv expected to send 1 low 1 high got %v", sent)
+	}
+	if c.Hash() != start {
+		t.Fatalf("Circuit expected back in its starting state after a press")
+	}
+
+	c = Parse(example2)
+	low, high := 0, 0
+	for i := 0; i < 1000; i++ {
+		s := c.Press()
+		low += s.Low
+		high += s.High
+	}
+	if low != 4250 || high != 2750 {
+		t.Fatalf("1000 presses expected 4250 low 2750 high got %d low %d high", low, high)
+	}
+	if c.Presses() != 1000 {
+		t.Fatalf("Presses expected 1000 got %d", c.Presses())
+	}
+}
+
+func TestState(t *testing.T) {
+	c := Parse(example2)
+	start := c.State()
+	for press := 1; press <= 4; press++ {
+		c.Press()
+		if back := c.State() == start; back != (press == 4) {
+			t.Fatalf("State after press %d expected back at the start %v got %v", press, press == 4, back)
+		}
+	}
+
+	c.Press()
+	c.Reset()
+	if c.State() != start || c.Presses() != 0 {
+		t.Fatalf("Reset expected the starting state got %s", c.State())
+	}
+	if con, _ := c.Module("con"); !equal(con.(*Conjunction).Inputs(), []string{"a", "b"}) {
+		t.Fatalf("con expected inputs [a b] got %v", con.(*Conjunction).Inputs())
+	}
+}
+
+func equal(a, b []string) bool {
+	return strings.Join(a, ",") == strings.Join(b, ",")
+}
+
+// Sends the opposite of what it gets
+type inverter struct{}
+
+func (inverter) Kind() string                { return "!" }
+func (inverter) AppendState(b []byte) []byte { return b }
+func (inverter) Reset()                      {}
+
+func (inverter) Receive(_ string, l Level) (Level, bool) {
+	return 1 - l, true
+}
+
+func TestCustomKind(t *testing.T) {
+	RegisterKind("!", func() Module { return inverter{} })
+	c := Parse([]string{
+		"broadcaster -> not",
+		"!not -> out",
+	})
+
+	var trace bytes.Buffer
+	c.Trace(&trace)
+	c.Press()
+	c.Trace(nil)
+	c.Press()
+
+	exp := `{"press":1,"src":"button","dest":"broadcaster","level":"low"}
+{"press":1,"src":"broadcaster","dest":"not","level":"low"}
+{"press":1,"src":"not","dest":"out","level":"high"}
+`
+	if trace.String() != exp {
+		t.Fatalf("Trace expected\n%s\ngot\n%s", exp, trace.String())
+	}
+}
+
+func TestReadTrace(t *testing.T) {
+	c := Parse(example2)
+	var trace bytes.Buffer
+	c.Trace(&trace)
+	s1, s2 := c.Press(), c.Press()
+
+	events, err := ReadTrace(&trace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n1 := s1.Low + s1.High
+	if len(events) != n1+s2.Low+s2.High || events[n1-1].Press != 1 || events[n1].Press != 2 {
+		t.Fatalf("ReadTrace expected %d and %d events got %v", n1, s2.Low+s2.High, events)
+	}
+	first := Event{1, Pulse{"button", BROADCASTER, Low}}
+	last := Event{2, Pulse{"con", "output", High}}
+	if events[0] != first || events[len(events)-1] != last {
+		t.Fatalf("ReadTrace expected %v ... %v got %v", first, last, events)
+	}
+
+	if _, err := ReadTrace(strings.NewReader(`{"press":1,"src":"a","dest":"b","level":"loud"}`)); err == nil {
+		t.Fatalf("Expected error for unknown level")
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Parse(example2).WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	dot := buf.String()
+	for _, ln := range []string{
+		`"a" [shape=box];`,
+		`"con" [shape=diamond];`,
+		`"broadcaster" [shape=ellipse];`,
+		`"output" [shape=doublecircle];`,
+		`"a" -> "con";`,
+	} {
+		if !strings.Contains(dot, "  "+ln+"\n") {
+			t.Fatalf("WriteDOT expected a line %s in\n%s", ln, dot)
+		}
+	}
+	if !strings.HasPrefix(dot, "digraph circuit {\n") || !strings.HasSuffix(dot, "}\n") {
+		t.Fatalf("WriteDOT expected a digraph got\n%s", dot)
+	}
+}
@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+// A Solution solves a single day's puzzle. Parse turns the raw puzzle input
+// into T, and each part computes its answer from a freshly parsed T, so parts
+// are free to modify it. A part that isn't solved returns nil
+type Solution[T any] interface {
+	Parse(input string) T
+	Part1(T) any
+	Part2(T) any
+}
+
+// A registered Solution with its input type erased
+type Day struct {
+	Year  int
+	Day   int
+	parse func(string) any
+	parts [2]func(any) any
+	// Embedded examples, see RegisterExamples
+	examples fs.FS
+}
+
+// Parse the raw puzzle input
+func (self *Day) Parse(input string) any {
+	return self.parse(input)
+}
+
+// Solve part n (1 or 2) from parsed input
+func (self *Day) Part(n int, parsed any) any {
+	return self.parts[n-1](parsed)
+}
+
+// Parse the input and solve part n (1 or 2)
+func (self *Day) Solve(n int, input string) any {
+	return self.Part(n, self.Parse(input))
+}
+
+// Solve, turning a panic of the day into an error
+func (self *Day) TrySolve(n int, input string) (ans any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%d/%d p%d panicked: %v", self.Year, self.Day, n, r)
+		}
+	}()
+	return self.Solve(n, input), nil
+}
+
+var registry = make(map[int]map[int]Day)
+
+// Register a day's solution so the runner can find it. Days call this from an
+// init func
+func Register[T any](year, day int, sol Solution[T]) {
+	if registry[year] == nil {
+		registry[year] = make(map[int]Day)
+	}
+	if _, ok := registry[year][day]; ok {
+		panic(fmt.Sprintf("%d/%d registered twice", year, day))
+	}
+	registry[year][day] = Day{
+		Year: year,
+		Day:  day,
+		parse: func(input string) any {
+			return sol.Parse(input)
+		},
+		parts: [2]func(any) any{
+			func(in any) any { return sol.Part1(in.(T)) },
+			func(in any) any { return sol.Part2(in.(T)) },
+		},
+	}
+}
+
+func Lookup(year, day int) (Day, bool) {
+	d, ok := registry[year][day]
+	return d, ok
+}
+
+// All registered days of a year, in order
+func Days(year int) []Day {
+	var days []Day
+	for _, d := range registry[year] {
+		days = append(days, d)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Day < days[j].Day
+	})
+	return days
+}
+
+// All years with at least one registered day, in order
+func Years() []int {
+	var years []int
+	for y := range registry {
+		years = append(years, y)
+	}
+	sort.Ints(years)
+	return years
+}
+
+// Format an answer for printing and comparison
+func FormatAnswer(ans any) string {
+	if ans == nil {
+		return ""
+	}
+	return fmt.Sprint(ans)
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+// Sums the input's numbers in part 1 and counts them in part 2
+type countSolution struct{}
+
+func (countSolution) Parse(input string) []int {
+	return StrsToInts(NonEmptyLines(input))
+}
+
+func (countSolution) Part1(nums []int) any {
+	return Sum(nums)
+}
+
+func (countSolution) Part2(nums []int) any {
+	return len(nums)
+}
+
+var countExamples = fstest.MapFS{
+	"examples/example.txt":  {Data: []byte("1\n2\n3\n")},
+	"examples/example2.txt": {Data: []byte("10\n20\n")},
+	"examples/answers.json": {Data: []byte(`{"example": ["6", "3"], "example2": ["", "2"]}`)},
+}
+
+// Register the test day 1/1 the tests of every file look up
+func TestMain(m *testing.M) {
+	Register[[]int](1, 1, countSolution{})
+	RegisterExamples(1, 1, countExamples)
+	os.Exit(m.Run())
+}
+
+func TestTrySolve(t *testing.T) {
+	d, _ := Lookup(1, 1)
+	if ans, err := d.TrySolve(1, "1\n2\n"); err != nil || ans != 3 {
+		t.Fatalf("TrySolve expected 3 got %v (%v)", ans, err)
+	}
+	if _, err := d.TrySolve(2, "1\nx\n"); err == nil || !strings.Contains(err.Error(), "1/1 p2 panicked") {
+		t.Fatalf("TrySolve expected a panic error got %v", err)
+	}
+}
@@ -0,0 +1,316 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Module path the years and days are imported under
+const MODULE = "aoc"
+
+var dayTemplate = template.Must(template.New("day").Parse(`package day{{.Day}}
+
+import (
+	"embed"
+
+	"aoc/utils"
+)
+
+type solution struct{}
+
+func (solution) Parse(input string) []string {
+	return utils.NonEmptyLines(input)
+}
+
+func (solution) Part1(lines []string) any {
+	return nil
+}
+
+func (solution) Part2(lines []string) any {
+	return nil
+}
+
+//go:embed examples
+var examples embed.FS
+
+func init() {
+	utils.Register[[]string]({{.Year}}, {{.Day}}, solution{})
+	utils.RegisterExamples({{.Year}}, {{.Day}}, examples)
+}
+`))
+
+var dayTestTemplate = template.Must(template.New("dayTest").Parse(`package day{{.Day}}
+
+import (
+	"testing"
+
+	"aoc/utils/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	aoctest.TestExamples(t, {{.Year}}, {{.Day}})
+}
+`))
+
+var yearTemplate = template.Must(template.New("year").Parse(`// Package y{{.Year}} registers every Go day of {{.Year}} with the solution registry
+package y{{.Year}}
+
+import (
+{{- range .Imports}}
+	_ "{{.}}"
+{{- end}}
+)
+`))
+
+var yearTestTemplate = template.Must(template.New("yearTest").Parse(`package y{{.Year}}
+
+import (
+	"testing"
+
+	"aoc/utils/aoctest"
+)
+
+func TestAnswers(t *testing.T) {
+	aoctest.CheckAnswers(t, {{.Year}})
+}
+`))
+
+var mainTemplate = template.Must(template.New("main").Parse(`package main
+
+import (
+	"os"
+{{range .Imports}}
+	_ "{{.}}"
+{{- end}}
+	"aoc/utils"
+)
+
+func main() {
+	os.Exit(utils.Main(os.Args[1:]))
+}
+`))
+
+// Find the module root by walking up from dir to the nearest go.mod
+func FindModuleRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("no go.mod found")
+		}
+		dir = parent
+	}
+}
+
+// Render a template as gofmt'd Go source for fname
+func renderGo(fname string, tmpl *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	return src, nil
+}
+
+// Add a blank import of pkg to the Go source src of fname, unless it already
+// imports it, leaving the rest as it is. The import goes at the end of the
+// last import block, where gofmt sorts it in
+func addImport(fname string, src []byte, pkg string) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, fname, src, parser.ImportsOnly)
+	if err != nil {
+		return nil, err
+	}
+	var block *ast.GenDecl
+	for _, decl := range f.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.IMPORT {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			if spec.(*ast.ImportSpec).Path.Value == strconv.Quote(pkg) {
+				return src, nil
+			}
+		}
+		if gd.Rparen.IsValid() {
+			block = gd
+		}
+	}
+	if block == nil {
+		return nil, fmt.Errorf("%s: no import block to add %s to", fname, pkg)
+	}
+
+	at := fset.Position(block.Rparen).Offset
+	line := "\t_ " + strconv.Quote(pkg) + "\n"
+	if at > 0 && src[at-1] != '\n' {
+		line = "\n" + line
+	}
+	res := append(append(append([]byte(nil), src[:at]...), line...), src[at:]...)
+	if res, err = format.Source(res); err != nil {
+		return nil, fmt.Errorf("%s: %w", fname, err)
+	}
+	return res, nil
+}
+
+// Numbered subdirectories of dir holding a Go package with the file marker,
+// as import paths under prefix in gofmt's import order. A missing dir has none
+func goPackages(dir, prefix, marker string) ([]string, error) {
+	ents, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var pkgs []string
+	for _, ent := range ents {
+		if _, err := strconv.Atoi(ent.Name()); err != nil || !ent.IsDir() {
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(dir, ent.Name(), marker))
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) > 0 {
+			pkgs = append(pkgs, prefix+"/"+ent.Name())
+		}
+	}
+	sort.Strings(pkgs)
+	return pkgs, nil
+}
+
+// Create a new day under root from the template, with an example stub whose
+// answers still have to be filled in, and wire it into its year and the aoc
+// command. Everything is rendered before anything is written, and existing
+// year and command files only get the missing import. Returns the created or
+// updated files
+func Scaffold(root string, year, day int) ([]string, error) {
+	data := struct{ Year, Day int }{year, day}
+	yearDir := filepath.Join(root, strconv.Itoa(year))
+	dayDir := filepath.Join(yearDir, strconv.Itoa(day))
+	exDir := filepath.Join(dayDir, EXAMPLES_DIR)
+	yearPkg := fmt.Sprintf("%s/%d", MODULE, year)
+	dayPkg := fmt.Sprintf("%s/%d", yearPkg, day)
+
+	mainFile := filepath.Join(dayDir, "main.go")
+	if _, err := os.Stat(mainFile); err == nil {
+		return nil, fmt.Errorf("%s already exists", mainFile)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	type output struct {
+		fname string
+		src   []byte
+	}
+	var outputs []output
+	render := func(fname string, tmpl *template.Template, data any) error {
+		src, err := renderGo(fname, tmpl, data)
+		if err == nil {
+			outputs = append(outputs, output{fname, src})
+		}
+		return err
+	}
+	// add the import to fname if it exists, or render it from tmpl with
+	// the imports of the packages found plus pkg
+	wire := func(fname, pkg string, tmpl *template.Template, found func() ([]string, error)) error {
+		old, err := os.ReadFile(fname)
+		if err == nil {
+			src, err := addImport(fname, old, pkg)
+			if err == nil && !bytes.Equal(src, old) {
+				outputs = append(outputs, output{fname, src})
+			}
+			return err
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		pkgs, err := found()
+		if err != nil {
+			return err
+		}
+		if i := sort.SearchStrings(pkgs, pkg); i == len(pkgs) || pkgs[i] != pkg {
+			pkgs = Insert(pkgs, i, pkg)
+		}
+		return render(fname, tmpl, struct {
+			Year    int
+			Imports []string
+		}{year, pkgs})
+	}
+
+	outputs = append(outputs,
+		output{filepath.Join(exDir, "example.txt"), nil},
+		output{filepath.Join(exDir, ANSWERS_FILE), []byte("{\n  \"example\": [\"\", \"\"]\n}\n")},
+	)
+	if err := render(mainFile, dayTemplate, data); err != nil {
+		return nil, err
+	}
+	if err := render(filepath.Join(dayDir, "main_test.go"), dayTestTemplate, data); err != nil {
+		return nil, err
+	}
+
+	// a new year needs its answer tests too
+	yearTest := filepath.Join(yearDir, "answers_test.go")
+	if _, err := os.Stat(yearTest); errors.Is(err, fs.ErrNotExist) {
+		if err := render(yearTest, yearTestTemplate, data); err != nil {
+			return nil, err
+		}
+	}
+
+	err := wire(filepath.Join(yearDir, "days.go"), dayPkg, yearTemplate, func() ([]string, error) {
+		return goPackages(yearDir, yearPkg, "*.go")
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = wire(filepath.Join(root, "cmd", "aoc", "main.go"), yearPkg, mainTemplate, func() ([]string, error) {
+		return goPackages(root, MODULE, "days.go")
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, out := range outputs {
+		if err := os.MkdirAll(filepath.Dir(out.fname), 0o755); err != nil {
+			return files, err
+		}
+		if err := os.WriteFile(out.fname, out.src, 0o644); err != nil {
+			return files, err
+		}
+		files = append(files, out.fname)
+	}
+	return files, nil
+}
+
+// Relative paths of files under root, for printing
+func relPaths(root string, files []string) string {
+	var rel []string
+	for _, f := range files {
+		if r, err := filepath.Rel(root, f); err == nil {
+			f = r
+		}
+		rel = append(rel, f)
+	}
+	return strings.Join(rel, "\n")
+}
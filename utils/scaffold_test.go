@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScaffold(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "2023", "1"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, day := range []int{10, 2} {
+		if _, err := Scaffold(root, 2023, day); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := Scaffold(root, 2023, 2); err == nil {
+		t.Fatalf("Expected error scaffolding an existing day")
+	}
+
+	read := func(parts ...string) string {
+		buf, err := os.ReadFile(filepath.Join(append([]string{root}, parts...)...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(buf)
+	}
+	days := read("2023", "days.go")
+	if !strings.Contains(days, "package y2023") || !strings.Contains(days, "_ \"aoc/2023/10\"\n\t_ \"aoc/2023/2\"\n") {
+		t.Fatalf("days.go expected 2023/10 and 2023/2 got\n%s", days)
+	}
+	if strings.Contains(days, "aoc/2023/1\"") {
+		t.Fatalf("days.go shouldn't import a day without Go files\n%s", days)
+	}
+	if main := read("cmd", "aoc", "main.go"); !strings.Contains(main, "_ \"aoc/2023\"") {
+		t.Fatalf("main.go expected to import aoc/2023 got\n%s", main)
+	}
+	if day := read("2023", "2", "main.go"); !strings.Contains(day, "utils.Register[[]string](2023, 2, solution{})") {
+		t.Fatalf("Day expected to register 2023/2 got\n%s", day)
+	}
+	if test := read("2023", "2", "main_test.go"); !strings.Contains(test, "aoctest.TestExamples(t, 2023, 2)") {
+		t.Fatalf("main_test.go expected to test 2023/2 with aoctest got\n%s", test)
+	}
+	if answers := read("2023", "answers_test.go"); !strings.Contains(answers, "aoctest.CheckAnswers(t, 2023)") {
+		t.Fatalf("answers_test.go expected to check 2023 with aoctest got\n%s", answers)
+	}
+
+	ex, err := LoadExamples(os.DirFS(filepath.Join(root, "2023", "2")))
+	if err != nil || ex.Answers["example"] != [2]string{} {
+		t.Fatalf("Expected an empty example answers entry got %v (%v)", ex.Answers, err)
+	}
+}
+
+func TestScaffoldKeepsEdits(t *testing.T) {
+	root := t.TempDir()
+	cmdFile := filepath.Join(root, "cmd", "aoc", "main.go")
+	if err := os.MkdirAll(filepath.Dir(cmdFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	edited := "package main\n\nimport (\n\t\"os\"\n\n\t_ \"aoc/2023\"\n\t\"aoc/utils\"\n)\n\n// edited by hand\nfunc main() {\n\tos.Exit(utils.Main(os.Args[1:]))\n}\n"
+	if err := os.WriteFile(cmdFile, []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Scaffold(root, 2021, 1); err != nil {
+		t.Fatal(err)
+	}
+	buf, err := os.ReadFile(cmdFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := strings.Replace(edited, "\t_ \"aoc/2023\"\n", "\t_ \"aoc/2021\"\n\t_ \"aoc/2023\"\n", 1)
+	if string(buf) != expected {
+		t.Fatalf("main.go expected\n%s\ngot\n%s", expected, buf)
+	}
+}
+
+func TestScaffoldNothingOnError(t *testing.T) {
+	root := t.TempDir()
+	cmdFile := filepath.Join(root, "cmd", "aoc", "main.go")
+	if err := os.MkdirAll(filepath.Dir(cmdFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// nowhere to add the year's import
+	if err := os.WriteFile(cmdFile, []byte("package main\n\nfunc main() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := Scaffold(root, 2023, 1)
+	if err == nil || len(files) != 0 {
+		t.Fatalf("Expected an error and no files got %v (%v)", files, err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "2023")); err == nil {
+		t.Fatalf("Expected nothing written under 2023")
+	}
+}